@@ -3,6 +3,7 @@ package config
 type ApiConfig struct {
 	Host         string
 	Port         int
+	GrpcPort     int
 	Env          string
 	DB           DbConfig
 	AlphaVantage struct {
@@ -14,6 +15,10 @@ type ApiConfig struct {
 		Burst   int
 		Enabled bool
 	}
+	Compression struct {
+		Enabled bool
+		MinSize int
+	}
 	Cors struct {
 		TrustedOrigins []string
 	}
@@ -15,6 +15,7 @@ const (
 	logLevel           = "log-level"
 	host               = "host"
 	port               = "port"
+	grpcPort           = "grpc-port"
 	env                = "env"
 	dbDsn              = "db-dsn"
 	dbMaxOpenConns     = "db-max-open-conns"
@@ -26,6 +27,8 @@ const (
 	rateLimiterBurst   = "limiter-burst"
 	rateLimiterEnabled = "limiter-enabled"
 	dataSyncEnable     = "data-sync-enable"
+	compressionEnabled = "compression-enabled"
+	compressionMinSize = "compression-min-size"
 
 	smtpHost     = "smtp-host"
 	smtpPort     = "smtp-port"
@@ -40,12 +43,14 @@ const (
 	cors       = "cors-trusted-origins"
 
 	defaultPort           = 9091
+	defaultGrpcPort       = 9092
 	defaultMaxOpenConns   = 25
 	defaultMaxIdleConns   = 25
 	defaultMaxIdleTime    = "15m"
 	defaultRatePerSeconds = 2
 	defaultRateBurst      = 4
 	defaultCors           = "http://localhost:9090"
+	defaultCompressionMin = 1024
 
 	defaultSmtpPort = 25
 )
@@ -66,6 +71,7 @@ func RunApiCmd() *cobra.Command {
 	runCmd.Flags().StringVar(&cfg.LogLevel, logLevel, "INFO", "logging level [DEBUG,INFO,WARNING,ERROR,FATAL]")
 	runCmd.Flags().StringVar(&cfg.Host, host, "localhost", "Swap Shop API hostname")
 	runCmd.Flags().IntVar(&cfg.Port, port, defaultPort, "Pulse API port number")
+	runCmd.Flags().IntVar(&cfg.GrpcPort, grpcPort, defaultGrpcPort, "Pulse gRPC API port number")
 	runCmd.Flags().StringVar(&cfg.Env, env, dev, fmt.Sprintf("%s|%s|%s|%s", dev, staging, uat, production))
 
 	// POSTGRESQL
@@ -83,6 +89,10 @@ func RunApiCmd() *cobra.Command {
 	runCmd.Flags().IntVar(&cfg.Limiter.Burst, rateLimiterBurst, defaultRateBurst, "Rate limiter maximum burst")
 	runCmd.Flags().BoolVar(&cfg.Limiter.Enabled, rateLimiterEnabled, true, "Enable rate limiter")
 
+	// Response compression
+	runCmd.Flags().BoolVar(&cfg.Compression.Enabled, compressionEnabled, true, "Enable gzip, brotli and zstd response compression")
+	runCmd.Flags().IntVar(&cfg.Compression.MinSize, compressionMinSize, defaultCompressionMin, "Minimum response size in bytes to compress")
+
 	// CORS
 	runCmd.Flags().StringSliceVar(&cfg.Cors.TrustedOrigins, cors, []string{defaultCors}, "all the Cors trusted origin URLS, usage: --cors-trusted-origin=url1,url2")
 	cfg.Cors.TrustedOrigins = strings.Fields(os.Getenv("PULSE_CORS_TRUSTED_ORIGIN"))
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"fmt"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+const (
+	windowParam = "window"
+	fnParam     = "fn"
+	methodParam = "method"
+)
+
+func (app *application) rollingHandler(s data.Series) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		app.rolling(s.ReportType, w, r)
+	}
+}
+
+func (app *application) treasuryYieldRolling(w http.ResponseWriter, r *http.Request) {
+	reportType := reportTypeByTreasuryMaturity(w, r, app)
+	if reportType != nil {
+		app.rolling(*reportType, w, r)
+	}
+}
+
+func (app *application) rolling(report data.ReportType, w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Filter data.SeriesFilter
+		Window data.RollingWindow
+		Paging data.Paging
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Filter = app.readSeriesFilter(qs, report, v)
+	input.Window.Size = app.readInt(qs, windowParam, 12, v)
+	for _, fn := range app.readCSV(qs, fnParam, []string{string(data.RollingMean)}) {
+		input.Window.Functions = append(input.Window.Functions, data.RollingFunction(fn))
+	}
+	input.Paging.Page = app.readInt(qs, pageParam, 1, v)
+	input.Paging.PageSize = app.readInt(qs, pageSizeParam, 12, v)
+
+	data.ValidatePaging(v, input.Paging)
+	data.ValidateSeriesFilter(v, input.Filter)
+	data.ValidateRollingWindow(v, input.Window)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	res, err := app.services.AnalyticsService.Rolling(r.Context(), report, input.Filter, input.Window, input.Paging)
+	if err != nil {
+		utils.Logger(r.Context()).Error("rolling error getting rolling stats", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{
+		"data": res.Data,
+		"meta": res.Meta,
+	}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("rolling error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) correlationHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Reports []data.ReportType
+		Filter  data.SeriesFilter
+		Method  data.CorrelationMethod
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	slugs := app.readCSV(qs, seriesParam, []string{})
+	input.Reports = app.readReportTypes(slugs, v)
+	input.Filter = app.readSeriesFilter(qs, data.Unknown, v)
+	if input.Filter.Frequency == data.FrequencyNative {
+		input.Filter.Frequency = data.FrequencyMonthly
+	}
+	input.Method = data.CorrelationMethod(app.readString(qs, methodParam, string(data.CorrelationPearson)))
+
+	v.Check(len(slugs) >= 2, seriesParam, "must contain at least two series")
+	v.Check(len(slugs) <= maxSeriesCount, seriesParam, fmt.Sprintf("must contain at most %d series", maxSeriesCount))
+	v.Check(validator.Unique(slugs), seriesParam, "must not contain duplicate series")
+	v.Check(input.Method.Valid(), methodParam, "must be one of pearson or spearman")
+	data.ValidateSeriesFilter(v, input.Filter)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	matrix, err := app.services.AnalyticsService.Correlation(r.Context(), input.Reports, input.Filter, input.Method)
+	if err != nil {
+		utils.Logger(r.Context()).Error("correlationHandler error correlating series", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{
+		"data": matrix,
+		"meta": map[string]interface{}{
+			"from": input.Filter.DateRange.From,
+			"to":   input.Filter.DateRange.To,
+		},
+	}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("correlationHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
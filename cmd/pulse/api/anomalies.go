@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+// anomaliesHandler lists the outliers of every report observed since the since param, a month ago by default
+func (app *application) anomaliesHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Since  time.Time
+		Paging data.Paging
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Since = app.readDate(qs, sinceParam, time.Now().AddDate(0, -1, 0), v)
+	input.Paging.Page = app.readInt(qs, pageParam, 1, v)
+	input.Paging.PageSize = app.readInt(qs, pageSizeParam, 12, v)
+
+	data.ValidatePaging(v, input.Paging)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	res, err := app.services.AnomalyService.Anomalies(r.Context(), input.Since, input.Paging)
+	if err != nil {
+		utils.Logger(r.Context()).Error("anomaliesHandler error getting anomalies", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{
+		"data": res.Data,
+		"meta": res.Meta,
+	}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("anomaliesHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/mhamm84/gofinance-alpha/alpha"
 	"github.com/mhamm84/pulse-api/cmd/config"
 	"github.com/mhamm84/pulse-api/cmd/pulse/helper"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/data/postgres"
 	"github.com/mhamm84/pulse-api/internal/mailer"
 	"github.com/mhamm84/pulse-api/internal/repo"
 	"github.com/mhamm84/pulse-api/internal/services"
@@ -20,6 +22,7 @@ import (
 type application struct {
 	cfg      config.ApiConfig
 	services services.ServicesModel
+	registry *data.SeriesRegistry
 	mailer   *mailer.Mailer
 	wg       *sync.WaitGroup
 }
@@ -40,20 +43,36 @@ func StartApi(cfg *config.ApiConfig) {
 	// SMTP mailer
 	mailer := mailer.New(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.Sender)
 
+	models := repo.NewModels(db)
+
+	ctx := context.TODO()
+
 	// Create the app
 	app := application{
 		cfg:      *cfg,
-		services: services.NewServicesModel(repo.NewModels(db), alphaClient, mailer),
+		services: services.NewServicesModel(models, alphaClient, mailer),
+		registry: newSeriesRegistry(ctx, models.ReportRepository),
 		mailer:   mailer,
 	}
 
-	ctx := context.TODO()
 	// Start the data sync tasks to keep data from the API up to date in the DB
 	if cfg.DataSync {
 		utils.Logger(ctx).Info("Starting startEconomicReportDataSync")
 		app.startEconomicReportDataSync()
 	}
 
+	// Push the events synced by every replica to this replica's stream clients
+	go func() {
+		err := app.services.StreamService.Listen(ctx, postgres.NewEventListener(cfg.DB.Dsn))
+		if err != nil {
+			utils.Logger(ctx).Error("error listening for economic events", zap.Error(err))
+		}
+	}()
+
+	// Drop the cached summaries and coverage as the data sync of every replica updates the series
+	go app.services.Economicdashservice.Listen(ctx)
+	go app.services.CatalogService.Listen(ctx)
+
 	logConfig(ctx, cfg)
 
 	// Serve the API
@@ -63,10 +82,31 @@ func StartApi(cfg *config.ApiConfig) {
 	}
 }
 
+// newSeriesRegistry builds the registry of the ReportType series with the metadata of their economic_report rows, the
+// series are still served without it if the rows can't be read. A row without a ReportType has no data to serve, so it
+// is logged rather than routed
+func newSeriesRegistry(ctx context.Context, reportRepository data.ReportRepository) *data.SeriesRegistry {
+	reports, err := reportRepository.GetAllReports(ctx)
+	if err != nil {
+		utils.Logger(ctx).Error("error getting reports for the series registry", zap.Error(err))
+	}
+	registry := data.NewSeriesRegistry(reports)
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		if _, ok := registry.Lookup(report.Slug); !ok {
+			utils.Logger(ctx).Warn("economic_report has no ReportType and isn't served", zap.String("slug", report.Slug))
+		}
+	}
+	return registry
+}
+
 func logConfig(ctx context.Context, cfg *config.ApiConfig) {
 	utils.Logger(ctx).Info("API",
 		zap.String("host", cfg.Host),
 		zap.Int("port", cfg.Port),
+		zap.Int("grpcPort", cfg.GrpcPort),
 		zap.String("env", cfg.Env),
 		zap.Strings("cors", cfg.Cors.TrustedOrigins),
 		zap.String("logLevel", cfg.LogLevel),
@@ -83,6 +123,10 @@ func logConfig(ctx context.Context, cfg *config.ApiConfig) {
 		zap.Float64("rps", cfg.Limiter.RPS),
 		zap.Int("username", cfg.Limiter.Burst),
 	)
+	utils.Logger(ctx).Info("Compression",
+		zap.Bool("enabled", cfg.Compression.Enabled),
+		zap.Int("minSize", cfg.Compression.MinSize),
+	)
 	utils.Logger(ctx).Info("DB",
 		zap.String("dsn", cfg.DB.Dsn),
 		zap.Int("port", cfg.DB.MaxOpenConns),
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/services"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mockUserService struct {
+	services.UserService
+	mock.Mock
+}
+
+func (m *mockUserService) GetFromToken(ctx context.Context, tokenScope, tokenplaintext string) (*data.User, error) {
+	args := m.Called(ctx, tokenScope, tokenplaintext)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.User), args.Error(1)
+}
+
+type mockPermissionsService struct {
+	mock.Mock
+}
+
+func (m *mockPermissionsService) GetAllForUser(ctx context.Context, userId int64) (data.Permissions, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(data.Permissions), args.Error(1)
+}
+
+// TestAuthentication checks the REST middleware and the gRPC interceptor treat each authorization header the same
+func TestAuthentication(t *testing.T) {
+	token := strings.Repeat("A", 26)
+	activated := &data.User{ID: 1, Activated: true}
+
+	tests := []struct {
+		name        string
+		header      string
+		user        *data.User
+		userErr     error
+		permissions data.Permissions
+		permErr     error
+		status      int
+		code        codes.Code
+	}{
+		{name: "No Header", status: http.StatusUnauthorized, code: codes.Unauthenticated},
+		{name: "Not Bearer", header: "Basic " + token, status: http.StatusUnauthorized, code: codes.Unauthenticated},
+		{name: "Malformed Token", header: "Bearer short", status: http.StatusUnauthorized, code: codes.Unauthenticated},
+		{name: "Unknown Token", header: "Bearer " + token, userErr: data.ErrRecordNotFound, status: http.StatusUnauthorized, code: codes.Unauthenticated},
+		{name: "Token Lookup Error", header: "Bearer " + token, userErr: errors.New("timeout"), status: http.StatusInternalServerError, code: codes.Internal},
+		{name: "Inactive User", header: "Bearer " + token, user: &data.User{ID: 2}, status: http.StatusForbidden, code: codes.PermissionDenied},
+		{name: "Not Permitted", header: "Bearer " + token, user: activated, permissions: data.Permissions{"users:all"}, status: http.StatusForbidden, code: codes.PermissionDenied},
+		{name: "Permission Lookup Error", header: "Bearer " + token, user: activated, permErr: errors.New("timeout"), status: http.StatusInternalServerError, code: codes.Internal},
+		{name: "Permitted", header: "Bearer " + token, user: activated, permissions: data.Permissions{economicPermission}, status: http.StatusOK, code: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := new(mockUserService)
+			users.On("GetFromToken", mock.Anything, data.ScopeAuthentication, token).Return(tt.user, tt.userErr)
+			permissions := new(mockPermissionsService)
+			permissions.On("GetAllForUser", mock.Anything, mock.Anything).Return(tt.permissions, tt.permErr)
+			app := &application{services: services.ServicesModel{UserService: users, PermissionsService: permissions}}
+
+			var user *data.User
+			handler := app.authenticate(app.requirePermissions(economicPermission, func(w http.ResponseWriter, r *http.Request) {
+				user = app.contextGetUser(r)
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/v1/economic/cpi", nil)
+			if tt.header != "" {
+				r.Header.Set(AuthorizationHeader, tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, r)
+
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(strings.ToLower(AuthorizationHeader), tt.header))
+			}
+			ctx, err := app.grpcAuthenticate(ctx, "/pulse.EconomicService/GetSeries")
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, tt.user, user)
+				assert.Equal(t, tt.user, ctx.Value(userContextKey))
+			}
+		})
+	}
+}
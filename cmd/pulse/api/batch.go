@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	itemsParam    = "items"
+	maxBatchItems = 25
+	// batchWorkers is the most items of a batch fetched at once, so a batch can't take every connection of the pool
+	batchWorkers = 4
+	// batchTimeout is the deadline shared by the items of a batch, it is under the server's timeout so the items which
+	// completed are still returned
+	batchTimeout = 4 * time.Second
+)
+
+// batchItem selects the series data of an item of a batch, the params are the ones of the series route
+type batchItem struct {
+	Series   string `json:"series"`
+	Years    *int   `json:"years"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Page     *int   `json:"page"`
+	PageSize *int   `json:"pageSize"`
+	Stats    bool   `json:"stats"`
+}
+
+// batchResult is the series data of an item of a batch or the error getting it, Status is the status the series route
+// would have responded with
+type batchResult struct {
+	Series string                     `json:"series"`
+	Status int                        `json:"status"`
+	Data   *[]data.EconomicWithChange `json:"data,omitempty"`
+	Meta   *data.Metadata             `json:"meta,omitempty"`
+	Stats  *[]data.EconomicStats      `json:"stats,omitempty"`
+	Error  interface{}                `json:"error,omitempty"`
+}
+
+// batchHandler gets the data of several series in one request. The items are fetched concurrently, each one gets its
+// own result or error in the order of the items
+func (app *application) batchHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Items []batchItem `json:"items"`
+	}
+
+	err := app.ReadJson(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r)
+		return
+	}
+
+	v := validator.New()
+	v.Check(len(input.Items) > 0, itemsParam, "must contain at least one item")
+	v.Check(len(input.Items) <= maxBatchItems, itemsParam, fmt.Sprintf("must contain at most %d items", maxBatchItems))
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), batchTimeout)
+	defer cancel()
+
+	results := make([]batchResult, len(input.Items))
+	items := make(chan int)
+	wg := new(sync.WaitGroup)
+	for worker := 0; worker < batchWorkers && worker < len(input.Items); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				results[i] = app.batchItem(ctx, input.Items[i])
+			}
+		}()
+	}
+	for i := range input.Items {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"data": results}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("batchHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// batchItem validates the item as the series route validates its query params, and gets its data
+func (app *application) batchItem(ctx context.Context, item batchItem) batchResult {
+	res := batchResult{Series: item.Series}
+
+	s, ok := app.registry.Lookup(item.Series)
+	if !ok {
+		res.Status = http.StatusNotFound
+		res.Error = map[string]string{seriesParam: fmt.Sprintf("unknown series %q", item.Series)}
+		return res
+	}
+
+	v := validator.New()
+	qs := item.query()
+	filter := app.readSeriesFilter(qs, s.ReportType, v)
+	paging := data.Paging{
+		Page:     app.readInt(qs, pageParam, 1, v),
+		PageSize: app.readInt(qs, pageSizeParam, 12, v),
+	}
+	data.ValidatePaging(v, paging)
+	data.ValidateSeriesFilter(v, filter)
+	if !v.Valid() {
+		res.Status = http.StatusUnprocessableEntity
+		res.Error = v.Errors
+		return res
+	}
+
+	page, err := app.seriesPage(ctx, s.ReportType, filter, paging)
+	if err == nil && item.Stats {
+		var stats *data.EconomicStatsResult
+		stats, err = app.seriesStats(ctx, s.ReportType, filter.DateRange, 365, data.DefaultStatsMetrics, paging)
+		if err == nil {
+			res.Stats = stats.Data
+		}
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			res.Status = http.StatusServiceUnavailable
+			res.Error = "the batch timed out before the series was fetched"
+		default:
+			utils.Logger(ctx).Error("batchItem error getting series", zap.Error(err), zap.String("series", s.Slug))
+			res.Status = http.StatusInternalServerError
+			res.Error = "the server encountered a problem and could not process your request"
+		}
+		res.Stats = nil
+		return res
+	}
+
+	res.Status = http.StatusOK
+	res.Data = page.Data
+	res.Meta = page.Meta
+	return res
+}
+
+// query is the item as the query params of the series route, so it is read with the same defaults and validation
+func (item batchItem) query() url.Values {
+	qs := url.Values{}
+	setInt := func(key string, value *int) {
+		if value != nil {
+			qs.Set(key, strconv.Itoa(*value))
+		}
+	}
+	setInt(yearsParam, item.Years)
+	setInt(pageParam, item.Page)
+	setInt(pageSizeParam, item.PageSize)
+	if item.From != "" {
+		qs.Set(fromParam, item.From)
+	}
+	if item.To != "" {
+		qs.Set(toParam, item.To)
+	}
+	return qs
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"github.com/julienschmidt/httprouter"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+func (app *application) reportsHandler(w http.ResponseWriter, r *http.Request) {
+	reports, err := app.services.CatalogService.Reports(r.Context())
+	if err != nil {
+		utils.Logger(r.Context()).Error("reportsHandler error getting the catalog", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"data": reports}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("reportsHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// reportHandler gets the catalog entry of a report, the slug is resolved through the registry so aliases work too
+func (app *application) reportHandler(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+	s, ok := app.registry.Lookup(params.ByName(slugParam))
+	if !ok {
+		app.notFoundHandler(w, r)
+		return
+	}
+
+	report, err := app.services.CatalogService.Report(r.Context(), s.Slug)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundHandler(w, r)
+		default:
+			utils.Logger(r.Context()).Error("reportHandler error getting the catalog entry", zap.Error(err), zap.String("slug", s.Slug))
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"data": report}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("reportHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
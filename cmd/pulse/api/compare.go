@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+const (
+	seriesParam    = "series"
+	alignParam     = "align"
+	maxSeriesCount = 10
+)
+
+func (app *application) compareHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Reports   []data.ReportType
+		Filter    data.SeriesFilter
+		Alignment data.Alignment
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	slugs := app.readCSV(qs, seriesParam, []string{})
+	input.Reports = app.readReportTypes(slugs, v)
+	input.Filter = app.readSeriesFilter(qs, data.Unknown, v)
+	input.Alignment = data.Alignment(app.readString(qs, alignParam, string(data.AlignInner)))
+
+	v.Check(len(slugs) >= 2, seriesParam, "must contain at least two series")
+	v.Check(len(slugs) <= maxSeriesCount, seriesParam, fmt.Sprintf("must contain at most %d series", maxSeriesCount))
+	v.Check(validator.Unique(slugs), seriesParam, "must not contain duplicate series")
+	v.Check(input.Alignment.Valid(), alignParam, "must be one of inner, outer or ffill")
+	data.ValidateSeriesFilter(v, input.Filter)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	rows, err := app.services.EconomicCompareService.Compare(r.Context(), input.Reports, input.Filter, input.Alignment)
+	if err != nil {
+		utils.Logger(r.Context()).Error("compareHandler error comparing series", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{
+		"data": rows,
+		"meta": map[string]interface{}{
+			"series":    slugs,
+			"alignment": input.Alignment,
+			"from":      input.Filter.DateRange.From,
+			"to":        input.Filter.DateRange.To,
+			"frequency": input.Filter.Frequency,
+		},
+	}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("compareHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// readReportTypes resolves each slug through the registry to its ReportType, adding a validation error for any unknown slug
+func (app *application) readReportTypes(slugs []string, v *validator.Validator) []data.ReportType {
+	reports := make([]data.ReportType, 0, len(slugs))
+	for _, slug := range slugs {
+		s, ok := app.registry.Lookup(strings.TrimSpace(slug))
+		if !ok {
+			v.AddError(seriesParam, fmt.Sprintf("unknown series %q", slug))
+			continue
+		}
+		reports = append(reports, s.ReportType)
+	}
+	return reports
+}
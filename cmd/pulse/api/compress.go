@@ -0,0 +1,222 @@
+package api
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingZstd   = "zstd"
+	encodingGzip   = "gzip"
+)
+
+// encodings are the supported content encodings in order of preference, when the client accepts several equally
+var encodings = []string{encodingBrotli, encodingZstd, encodingGzip}
+
+// encoder is the compressor of a content encoding, it is reset to write each response
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// encoderPools reuse the encoders, as their buffers are too large to allocate for each response
+var encoderPools = map[string]*sync.Pool{
+	encodingBrotli: {New: func() interface{} {
+		return brotli.NewWriterLevel(nil, 4)
+	}},
+	encodingZstd: {New: func() interface{} {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+		return enc
+	}},
+	encodingGzip: {New: func() interface{} {
+		enc, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return enc
+	}},
+}
+
+// negotiateEncoding picks the supported encoding the Accept-Encoding header gives the highest q value, an empty string
+// when the response shouldn't be compressed
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		qualities[coding] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range encodings {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressible is whether a response of the content type is worth compressing, an event stream isn't as each event
+// has to reach the client as it is written
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == contentTypeEventStream:
+		return false
+	case strings.HasPrefix(mediaType, "text/"),
+		mediaType == contentTypeJson,
+		mediaType == contentTypeNdjson,
+		strings.HasSuffix(mediaType, "+json"),
+		mediaType == "application/xml",
+		mediaType == "application/javascript":
+		return true
+	}
+	return false
+}
+
+// compressWriter compresses the response once the body reaches the minimum size. The body is buffered until then, so a
+// small response is written as it is, and a flush before then starts the compression as the response is streamed
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	encoder encoder
+	// started is set once the header is written, the body is compressed when the encoder is set
+	started bool
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.started || cw.status != 0 {
+		return
+	}
+	cw.status = status
+	// The header of a response which won't be compressed is written straight away, so it isn't held back from the client.
+	// Without a content type that isn't known until the body is sniffed
+	if cw.Header().Get("Content-Type") != "" && !cw.compressible() {
+		cw.start(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.started {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.minSize {
+		if err := cw.start(cw.compressible()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (cw *compressWriter) Flush() {
+	if !cw.started {
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		cw.start(cw.compressible())
+	}
+	if cw.encoder != nil {
+		cw.encoder.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// compressible is whether the response can be compressed, which depends on its status and headers so is only known
+// once it is being written
+func (cw *compressWriter) compressible() bool {
+	h := cw.Header()
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	contentType := h.Get("Content-Type")
+	if contentType == "" && len(cw.buf) > 0 {
+		contentType = http.DetectContentType(cw.buf)
+	}
+	return compressible(contentType)
+}
+
+// start writes the header and the buffered body, compressed or not
+func (cw *compressWriter) start(compress bool) error {
+	cw.started = true
+	if compress {
+		h := cw.Header()
+		h.Set("Content-Encoding", cw.encoding)
+		h.Del("Content-Length")
+		cw.encoder = encoderPools[cw.encoding].Get().(encoder)
+		cw.encoder.Reset(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(cw.buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf)
+	}
+	cw.buf = nil
+	return err
+}
+
+// close writes a response which never reached the minimum size as it is, or finishes the compressed body
+func (cw *compressWriter) close() error {
+	if !cw.started {
+		if cw.status == 0 {
+			return nil
+		}
+		return cw.start(false)
+	}
+	if cw.encoder == nil {
+		return nil
+	}
+	err := cw.encoder.Close()
+	// The encoder is reset so the pool doesn't hold on to the response
+	cw.encoder.Reset(io.Discard)
+	encoderPools[cw.encoding].Put(cw.encoder)
+	cw.encoder = nil
+	return err
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "None", acceptEncoding: "", want: ""},
+		{name: "Gzip", acceptEncoding: "gzip", want: encodingGzip},
+		{name: "Preference", acceptEncoding: "gzip, zstd, br", want: encodingBrotli},
+		{name: "Highest Q", acceptEncoding: "br;q=0.5, gzip;q=0.8", want: encodingGzip},
+		{name: "Case And Spaces", acceptEncoding: " GZIP ; q=0.9 ", want: encodingGzip},
+		{name: "Gzip Refused", acceptEncoding: "gzip;q=0", want: ""},
+		{name: "Refused Among Others", acceptEncoding: "br;q=0, gzip", want: encodingGzip},
+		{name: "Wildcard", acceptEncoding: "*", want: encodingBrotli},
+		{name: "Wildcard Refused", acceptEncoding: "*;q=0", want: ""},
+		{name: "Wildcard With Refusal", acceptEncoding: "br;q=0, *", want: encodingZstd},
+		{name: "Wildcard Lower Than Named", acceptEncoding: "*;q=0.1, gzip;q=0.5", want: encodingGzip},
+		{name: "Identity", acceptEncoding: "identity", want: ""},
+		{name: "Unsupported", acceptEncoding: "deflate, compress", want: ""},
+		{name: "Invalid Q", acceptEncoding: "gzip;q=high", want: encodingGzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, negotiateEncoding(tt.acceptEncoding))
+		})
+	}
+}
+
+func decompress(t *testing.T, encoding string, body []byte) string {
+	var r io.Reader
+	switch encoding {
+	case encodingGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		r = gr
+	case encodingBrotli:
+		r = brotli.NewReader(bytes.NewReader(body))
+	case encodingZstd:
+		zr, err := zstd.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	default:
+		return string(body)
+	}
+	res, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(res)
+}
+
+func TestCompress(t *testing.T) {
+	const minSize = 64
+	app := &application{}
+	app.cfg.Compression.Enabled = true
+	app.cfg.Compression.MinSize = minSize
+
+	large := `{"data":"` + strings.Repeat("pulse ", 100) + `"}`
+	small := `{"data":"pulse"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		contentType    string
+		body           string
+		encoding       string
+	}{
+		{name: "Gzip", acceptEncoding: "gzip", contentType: contentTypeJson, body: large, encoding: encodingGzip},
+		{name: "Brotli", acceptEncoding: "gzip, br", contentType: contentTypeJson, body: large, encoding: encodingBrotli},
+		{name: "Zstd", acceptEncoding: "zstd", contentType: contentTypeJson, body: large, encoding: encodingZstd},
+		{name: "Wildcard", acceptEncoding: "*", contentType: contentTypeJson, body: large, encoding: encodingBrotli},
+		{name: "Gzip Refused", acceptEncoding: "gzip;q=0", contentType: contentTypeJson, body: large},
+		{name: "Identity", acceptEncoding: "identity", contentType: contentTypeJson, body: large},
+		{name: "No Accept Encoding", contentType: contentTypeJson, body: large},
+		{name: "Under Min Size", acceptEncoding: "gzip", contentType: contentTypeJson, body: small},
+		{name: "Not Compressible", acceptEncoding: "gzip", contentType: "image/png", body: large},
+		{name: "Sniffed Content Type", acceptEncoding: "gzip", body: large, encoding: encodingGzip},
+		{name: "Head", method: http.MethodHead, acceptEncoding: "gzip", contentType: contentTypeJson, body: large},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := app.compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(http.StatusOK)
+				io.WriteString(w, tt.body)
+			}))
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			r := httptest.NewRequest(method, "/v1/economic/cpi", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, r)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Header().Values("Vary"), "Accept-Encoding")
+			assert.Equal(t, tt.encoding, rr.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.body, decompress(t, tt.encoding, rr.Body.Bytes()))
+		})
+	}
+}
+
+func TestCompress_EventStream(t *testing.T) {
+	app := &application{}
+	app.cfg.Compression.Enabled = true
+	app.cfg.Compression.MinSize = 64
+
+	rr := httptest.NewRecorder()
+	event := "id: 1\nevent: observation\ndata: {\"slug\":\"cpi\"}\n\n"
+	handler := app.compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentTypeEventStream)
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 3; i++ {
+			io.WriteString(w, event)
+			w.(http.Flusher).Flush()
+			// each event reaches the client as it is flushed
+			assert.Equal(t, strings.Repeat(event, i+1), rr.Body.String())
+		}
+	}))
+	r := httptest.NewRequest(http.MethodGet, WithVersion(streamPath), nil)
+	r.Header.Set("Accept-Encoding", "gzip, br")
+	handler.ServeHTTP(rr, r)
+
+	assert.True(t, rr.Flushed)
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Contains(t, rr.Header().Values("Vary"), "Accept-Encoding")
+	assert.Equal(t, strings.Repeat(event, 3), rr.Body.String())
+}
+
+func TestCompress_FlushStartsCompression(t *testing.T) {
+	app := &application{}
+	app.cfg.Compression.Enabled = true
+	app.cfg.Compression.MinSize = 1024
+
+	rr := httptest.NewRecorder()
+	handler := app.compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentTypeNdjson)
+		io.WriteString(w, "{\"a\":1}\n")
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "{\"a\":2}\n")
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/v1/economic/cpi", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	handler.ServeHTTP(rr, r)
+
+	assert.True(t, rr.Flushed)
+	assert.Equal(t, encodingGzip, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "{\"a\":1}\n{\"a\":2}\n", decompress(t, encodingGzip, rr.Body.Bytes()))
+}
+
+func TestCompress_Disabled(t *testing.T) {
+	app := &application{}
+
+	rr := httptest.NewRecorder()
+	body := strings.Repeat("pulse ", 1000)
+	handler := app.compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/v1/economic/cpi", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	handler.ServeHTTP(rr, r)
+
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, rr.Body.String())
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+)
+
+// dashboardInput is the body of the create and update routes, the version is optional and guards the update against
+// an edit made since the client read the dashboard
+type dashboardInput struct {
+	Name    string              `json:"name"`
+	Items   data.DashboardItems `json:"items"`
+	Version *int                `json:"version,omitempty"`
+}
+
+// listDashboardsHandler gets the dashboards of the user, the default dashboard when they haven't created any
+func (app *application) listDashboardsHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	dashboards, err := app.services.Economicdashservice.Dashboards(r.Context(), user.ID)
+	if err != nil {
+		utils.Logger(r.Context()).Error("listDashboardsHandler error getting dashboards", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"dashboards": dashboards}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("listDashboardsHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) createDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	var input dashboardInput
+	err := app.ReadJson(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r)
+		return
+	}
+
+	dashboard := &data.Dashboard{
+		UserID: app.contextGetUser(r).ID,
+		Name:   input.Name,
+		Items:  input.Items,
+	}
+	if v := app.validateDashboard(dashboard); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.services.Economicdashservice.CreateDashboard(r.Context(), dashboard)
+	if err != nil {
+		utils.Logger(r.Context()).Error("createDashboardHandler error creating dashboard", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/%s/dashboards/%d", apiVersion, dashboard.ID))
+
+	err = app.WriteJson(w, http.StatusCreated, envelope{"dashboard": dashboard}, headers)
+	if err != nil {
+		utils.Logger(r.Context()).Error("createDashboardHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// showDashboardHandler gets a dashboard of the user with the summaries of its series
+func (app *application) showDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := readDashboardID(r)
+	if !ok {
+		app.notFoundHandler(w, r)
+		return
+	}
+
+	dashboard, err := app.services.Economicdashservice.Dashboard(r.Context(), id, app.contextGetUser(r).ID)
+	if err != nil {
+		app.dashboardErrorResponse(w, r, err, "showDashboardHandler error getting dashboard")
+		return
+	}
+
+	summaries, err := app.services.Economicdashservice.Summaries(r.Context(), dashboard.Items)
+	if err != nil {
+		utils.Logger(r.Context()).Error("showDashboardHandler error getting summaries", zap.Error(err), zap.Int64("id", id))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"dashboard": dashboard, "economicSummaries": summaries}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("showDashboardHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// updateDashboardHandler replaces the name and items of a dashboard of the user, the default dashboard can't be updated
+func (app *application) updateDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := readDashboardID(r)
+	if !ok || id == 0 {
+		app.notFoundHandler(w, r)
+		return
+	}
+
+	dashboard, err := app.services.Economicdashservice.Dashboard(r.Context(), id, app.contextGetUser(r).ID)
+	if err != nil {
+		app.dashboardErrorResponse(w, r, err, "updateDashboardHandler error getting dashboard")
+		return
+	}
+
+	var input dashboardInput
+	err = app.ReadJson(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r)
+		return
+	}
+	if input.Version != nil && *input.Version != dashboard.Version {
+		app.editConflictResponse(w, r)
+		return
+	}
+
+	dashboard.Name = input.Name
+	dashboard.Items = input.Items
+	if v := app.validateDashboard(dashboard); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.services.Economicdashservice.UpdateDashboard(r.Context(), dashboard)
+	if err != nil {
+		app.dashboardErrorResponse(w, r, err, "updateDashboardHandler error updating dashboard")
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"dashboard": dashboard}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("updateDashboardHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) deleteDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := readDashboardID(r)
+	if !ok || id == 0 {
+		app.notFoundHandler(w, r)
+		return
+	}
+
+	err := app.services.Economicdashservice.DeleteDashboard(r.Context(), id, app.contextGetUser(r).ID)
+	if err != nil {
+		app.dashboardErrorResponse(w, r, err, "deleteDashboardHandler error deleting dashboard")
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"message": "dashboard successfully deleted"}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("deleteDashboardHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// validateDashboard resolves the series of each item through the registry to its slug, defaulting the change to pop
+// and the name to the report's display name, and validates the dashboard
+func (app *application) validateDashboard(dashboard *data.Dashboard) *validator.Validator {
+	v := validator.New()
+	for i, item := range dashboard.Items {
+		s, ok := app.registry.Lookup(item.Series)
+		if !ok {
+			v.AddError("items", fmt.Sprintf("unknown series %q", item.Series))
+			continue
+		}
+		item.Series = s.Slug
+		if item.Change == "" {
+			item.Change = data.ChangePeriodOverPeriod
+		}
+		if item.Name == "" && s.Report != nil {
+			item.Name = s.Report.DisplayName
+		}
+		dashboard.Items[i] = item
+	}
+	data.ValidateDashboard(v, dashboard)
+	return v
+}
+
+// readDashboardID reads the :id path param, 0 is the id of the default dashboard
+func readDashboardID(r *http.Request) (int64, bool) {
+	params := httprouter.ParamsFromContext(r.Context())
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil || id < 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func (app *application) dashboardErrorResponse(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	switch {
+	case errors.Is(err, data.ErrRecordNotFound):
+		app.notFoundHandler(w, r)
+	case errors.Is(err, data.ErrEditConflict):
+		app.editConflictResponse(w, r)
+	default:
+		utils.Logger(r.Context()).Error(msg, zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -9,107 +9,52 @@ import (
 	"github.com/mhamm84/pulse-api/internal/validator"
 	"go.uber.org/zap"
 	"net/http"
+	"net/url"
 	"sync"
+	"time"
 )
 
 const (
+	slugParam           = "slug"
 	yearsParam          = "years"
+	fromParam           = "from"
+	toParam             = "to"
+	asOfParam           = "asOf"
+	frequencyParam      = "frequency"
+	aggParam            = "agg"
+	changeParam         = "change"
+	lagParam            = "lag"
 	timeBucketDaysParam = "timeBucketDays"
+	metricsParam        = "metrics"
 	pageParam           = "page"
 	pageSizeParam       = "pageSize"
 )
 
-func (app *application) inflationExpectation(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.InflationExpectation, w, r)
-}
-
-func (app *application) inflationExpectationStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.InflationExpectation, w, r)
-}
-
-func (app *application) inflation(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.Inflation, w, r)
-}
-
-func (app *application) inflationStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.Inflation, w, r)
-}
-
-func (app *application) nonfarmPayroll(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.NonfarmPayroll, w, r)
-}
-func (app *application) nonfarmPayrollStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.NonfarmPayroll, w, r)
-}
-
-func (app *application) unemployemnt(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.Unemployment, w, r)
-}
-
-func (app *application) unemployemntStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.Unemployment, w, r)
-}
-
-func (app *application) durableGoodsOrders(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.DurableGoodsOrders, w, r)
-}
-
-func (app *application) durableGoodsOrdersStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.DurableGoodsOrders, w, r)
-}
-
-func (app *application) federalFundsRate(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.FederalFundsRate, w, r)
-}
-
-func (app *application) federalFundsRateStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.FederalFundsRate, w, r)
-}
-
-func (app *application) realGdpPerCapitaDataByYears(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.RealGdpPerCapita, w, r)
-}
-
-func (app *application) realGdpPerCapitaStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.RealGdpPerCapita, w, r)
-}
-
-func (app *application) realGdpDataByYears(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.RealGDP, w, r)
-}
-
-func (app *application) realGdpDataByYearsStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.RealGDP, w, r)
-}
-
-// ###############################################################################################
-// CPI
-// ###############################################################################################
-func (app *application) cpiDataByYears(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.CPI, w, r)
-}
-
-func (app *application) cpiStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.CPI, w, r)
-}
-
-// ###############################################################################################
-// Consumer Sentiment
-// ###############################################################################################
-func (app *application) consumerSentimentDataByYears(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.ConsumerSentiment, w, r)
-}
-
-func (app *application) consumerSentimentStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.ConsumerSentiment, w, r)
+// seriesRoute resolves the :slug param of a series route through the registry and serves the series with the handler,
+// a slug which isn't in the registry is not found
+func (app *application) seriesRoute(handler func(s data.Series) http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s, ok := app.registry.Lookup(httprouter.ParamsFromContext(r.Context()).ByName(slugParam))
+		if !ok {
+			app.notFoundHandler(w, r)
+			return
+		}
+		handler(s)(w, r)
+	}
 }
 
-func (app *application) retailSalesDataByYears(w http.ResponseWriter, r *http.Request) {
-	getEconomicDataByYears(r.Context(), app, data.RetailSales, w, r)
+// seriesHandler serves the data of a series resolved from the registry
+func (app *application) seriesHandler(s data.Series) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		getEconomicDataByYears(r.Context(), app, s.ReportType, w, r)
+	}
 }
 
-func (app *application) retailSalesDataByYearsStats(w http.ResponseWriter, r *http.Request) {
-	getStats(r.Context(), app, data.RetailSales, w, r)
+// seriesStatsHandler serves the bucketed stats of a series resolved from the registry
+func (app *application) seriesStatsHandler(s data.Series) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		getStats(r.Context(), app, s.ReportType, w, r)
+	}
 }
 
 func (app *application) treasuryYieldByYears(w http.ResponseWriter, r *http.Request) {
@@ -142,27 +87,42 @@ func reportTypeByTreasuryMaturity(w http.ResponseWriter, r *http.Request, app *a
 
 func getStats(ctx context.Context, app *application, report data.ReportType, w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Years          int
+		DateRange      data.DateRange
 		TimeBucketDays int
+		Metrics        data.StatsMetrics
 		Paging         data.Paging
 	}
 
 	v := validator.New()
 
 	qs := r.URL.Query()
-	years := app.readInt(qs, yearsParam, 10, v)
-	input.Years = years
+	input.DateRange = app.readDateRange(qs, report, v)
+	format := app.readFormat(r, v)
 	timeBucketDays := app.readInt(qs, timeBucketDaysParam, 365, v)
 	input.TimeBucketDays = timeBucketDays
+	for _, metric := range app.readCSV(qs, metricsParam, data.DefaultStatsMetrics.Strings()) {
+		input.Metrics = append(input.Metrics, data.StatsMetric(metric))
+	}
 	page := app.readInt(qs, pageParam, 1, v)
 	input.Paging.Page = page
 	pageSize := app.readInt(qs, pageSizeParam, 12, v)
 	input.Paging.PageSize = pageSize
 
 	data.ValidatePaging(v, input.Paging)
-	checkYears(years, report, v)
+	data.ValidateDateRange(v, input.DateRange)
+	data.ValidateStatsMetrics(v, input.Metrics)
 	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	if format != formatJson {
+		rw := economicStatsWriter(w, format, report, input.Metrics)
+		err := app.services.AlphaVantageEconomicService.EachStats(ctx, report, input.DateRange, timeBucketDays, input.Metrics, input.Paging, rw.start, rw.write)
+		rw.finish(app, r, "getStats", err)
+		return
 	}
 
 	wg := new(sync.WaitGroup)
@@ -170,7 +130,7 @@ func getStats(ctx context.Context, app *application, report data.ReportType, w h
 	dataChan := make(chan data.EconomicStatsResult)
 	errChan := make(chan error)
 
-	go app.services.AlphaVantageEconomicService.GetStats(r.Context(), wg, dataChan, errChan, report, years, timeBucketDays, input.Paging)
+	go app.services.AlphaVantageEconomicService.GetStats(r.Context(), wg, dataChan, errChan, report, input.DateRange, timeBucketDays, input.Metrics, input.Paging)
 
 	select {
 	case data := <-dataChan:
@@ -195,21 +155,57 @@ func getStats(ctx context.Context, app *application, report data.ReportType, w h
 }
 
 func checkYears(years int, report data.ReportType, v *validator.Validator) {
-	v.Check(years > 0, fmt.Sprintf("%s.years", report), "years must be a positive value")
+	key := yearsParam
+	if report != data.Unknown {
+		key = fmt.Sprintf("%s.years", report)
+	}
+	v.Check(years > 0, key, "years must be a positive value")
+}
+
+// readDateRange reads the from/to query params, falling back to the years shorthand
+// counting back from today when neither is provided, and the asOf date of the vintage to return
+func (app *application) readDateRange(qs url.Values, report data.ReportType, v *validator.Validator) data.DateRange {
+	now := time.Now()
+	var dateRange data.DateRange
+	if qs.Get(fromParam) == "" && qs.Get(toParam) == "" {
+		years := app.readInt(qs, yearsParam, 10, v)
+		checkYears(years, report, v)
+		dateRange = data.DateRangeFromYears(years, now)
+	} else {
+		dateRange = data.DateRange{
+			From: app.readDate(qs, fromParam, time.Time{}, v),
+			To:   app.readDate(qs, toParam, now, v),
+		}
+	}
+	dateRange.AsOf = app.readDate(qs, asOfParam, time.Time{}, v)
+	return dateRange
+}
+
+// readSeriesFilter reads the date range along with the optional resampling frequency, aggregation and change calculation
+func (app *application) readSeriesFilter(qs url.Values, report data.ReportType, v *validator.Validator) data.SeriesFilter {
+	return data.SeriesFilter{
+		DateRange:   app.readDateRange(qs, report, v),
+		Frequency:   data.Frequency(app.readString(qs, frequencyParam, string(data.FrequencyNative))),
+		Aggregation: data.Aggregation(app.readString(qs, aggParam, string(data.AggregationLast))),
+		Change: data.Change{
+			Type: data.ChangeType(app.readString(qs, changeParam, string(data.DefaultChange.Type))),
+			Lag:  app.readInt(qs, lagParam, data.DefaultChange.Lag, v),
+		},
+	}
 }
 
 func getEconomicDataByYears(ctx context.Context, app *application, report data.ReportType, w http.ResponseWriter, r *http.Request) {
 
 	var input struct {
-		Years  int
+		Filter data.SeriesFilter
 		Paging data.Paging
 	}
 
 	v := validator.New()
 
 	qs := r.URL.Query()
-	years := app.readInt(qs, yearsParam, 10, v)
-	input.Years = years
+	input.Filter = app.readSeriesFilter(qs, report, v)
+	format := app.readFormat(r, v)
 
 	page := app.readInt(qs, pageParam, 1, v)
 	input.Paging.Page = page
@@ -217,10 +213,16 @@ func getEconomicDataByYears(ctx context.Context, app *application, report data.R
 	input.Paging.PageSize = pageSize
 
 	data.ValidatePaging(v, input.Paging)
-
-	v.Check(years > 0, fmt.Sprintf("%s.years", report), "years must be a positive value")
+	data.ValidateSeriesFilter(v, input.Filter)
 	if !v.Valid() {
 		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+	if format != formatJson {
+		streamEconomicData(ctx, app, report, format, input.Filter, input.Paging, w, r)
+		return
 	}
 
 	dataChan := make(chan data.EconomicWithChangeResult)
@@ -230,8 +232,8 @@ func getEconomicDataByYears(ctx context.Context, app *application, report data.R
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
 
-	go app.services.AlphaVantageEconomicService.GetIntervalWithPercentChange(r.Context(), wg, dataChan, errChan, report, years, input.Paging)
-	go app.services.AlphaVantageEconomicService.GetStats(r.Context(), wg, statsChan, errChan, report, years, 365, input.Paging)
+	go app.services.AlphaVantageEconomicService.GetIntervalWithPercentChange(r.Context(), wg, dataChan, errChan, report, input.Filter, input.Paging)
+	go app.services.AlphaVantageEconomicService.GetStats(r.Context(), wg, statsChan, errChan, report, input.Filter.DateRange, 365, data.DefaultStatsMetrics, input.Paging)
 
 	envelope := envelope{}
 
@@ -261,3 +263,50 @@ func getEconomicDataByYears(ctx context.Context, app *application, report data.R
 		app.serverErrorResponse(w, r, err)
 	}
 }
+
+// streamEconomicData writes only the data rows in a streaming format, as they are read, the bucketed stats are available
+// from the stats endpoint
+func streamEconomicData(ctx context.Context, app *application, report data.ReportType, format responseFormat, filter data.SeriesFilter, paging data.Paging, w http.ResponseWriter, r *http.Request) {
+	rw := economicWithChangeWriter(w, format, report)
+	err := app.services.AlphaVantageEconomicService.EachPageWithPercentChange(ctx, report, filter, paging, rw.start, rw.write)
+	rw.finish(app, r, "streamEconomicData", err)
+}
+
+// seriesPage gets a page of the series from the EconomicService for callers which aren't writing an http response
+func (app *application) seriesPage(ctx context.Context, report data.ReportType, filter data.SeriesFilter, paging data.Paging) (*data.EconomicWithChangeResult, error) {
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	// Buffered so the service doesn't block when the context is done first
+	dataChan := make(chan data.EconomicWithChangeResult, 1)
+	errChan := make(chan error, 1)
+
+	go app.services.AlphaVantageEconomicService.GetIntervalWithPercentChange(ctx, wg, dataChan, errChan, report, filter, paging)
+
+	select {
+	case res := <-dataChan:
+		return &res, nil
+	case err := <-errChan:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// seriesStats gets a page of the bucketed stats from the EconomicService for callers which aren't writing an http response
+func (app *application) seriesStats(ctx context.Context, report data.ReportType, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging) (*data.EconomicStatsResult, error) {
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	dataChan := make(chan data.EconomicStatsResult, 1)
+	errChan := make(chan error, 1)
+
+	go app.services.AlphaVantageEconomicService.GetStats(ctx, wg, dataChan, errChan, report, dateRange, timeBucketDays, metrics, paging)
+
+	select {
+	case res := <-dataChan:
+		return &res, nil
+	case err := <-errChan:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// economicMethods are the methods the economic routes are served for, the others are left to the router
+var economicMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// economicRoutes serves the routes under /economic through a single catch-all route of each method. httprouter doesn't
+// allow the :slug wildcard of the series alongside the static economic routes, so the routes are matched here instead,
+// a static segment taking precedence over a param, and the params are set on the request context for the handlers as
+// usual. Unknown paths, methods, OPTIONS and redirects are handled as the router handles them for the other routes
+type economicRoutes struct {
+	prefix string
+	routes []economicRoute
+	// values are the values a param matches, a param without values matches any segment
+	values           map[string]map[string]bool
+	notFound         http.Handler
+	methodNotAllowed http.Handler
+}
+
+type economicRoute struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+func newEconomicRoutes(prefix string, notFound, methodNotAllowed http.Handler) *economicRoutes {
+	return &economicRoutes{prefix: prefix, values: make(map[string]map[string]bool), notFound: notFound, methodNotAllowed: methodNotAllowed}
+}
+
+// restrict matches the param only to the values, so a path with another value is not found rather than matched
+func (er *economicRoutes) restrict(param string, values []string) {
+	er.values[param] = make(map[string]bool, len(values))
+	for _, value := range values {
+		er.values[param][value] = true
+	}
+}
+
+// add adds the route of a path under the prefix, in the httprouter format
+func (er *economicRoutes) add(method, path string, handler http.HandlerFunc) {
+	er.routes = append(er.routes, economicRoute{
+		method:   method,
+		segments: strings.Split(strings.TrimPrefix(path, er.prefix), "/"),
+		handler:  handler,
+	})
+}
+
+func (er *economicRoutes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler, params := er.match(r.Method, strings.TrimPrefix(r.URL.Path, er.prefix))
+	if handler != nil {
+		handler(w, r.WithContext(context.WithValue(r.Context(), httprouter.ParamsKey, params)))
+		return
+	}
+
+	if path, ok := er.fixedPath(r.Method, r.URL.Path); ok {
+		code := http.StatusMovedPermanently
+		if r.Method != http.MethodGet {
+			code = http.StatusTemporaryRedirect
+		}
+		r.URL.Path = path
+		http.Redirect(w, r, r.URL.String(), code)
+		return
+	}
+
+	if allow := er.allowed(strings.TrimPrefix(r.URL.Path, er.prefix)); allow != "" {
+		w.Header().Set("Allow", allow)
+		if r.Method != http.MethodOptions {
+			er.methodNotAllowed.ServeHTTP(w, r)
+		}
+		return
+	}
+	er.notFound.ServeHTTP(w, r)
+}
+
+// match finds the most specific route of the method and path, the one whose first segment differing from the other
+// matches is static
+func (er *economicRoutes) match(method, path string) (http.HandlerFunc, httprouter.Params) {
+	segments := strings.Split(path, "/")
+
+	var best *economicRoute
+	for i := range er.routes {
+		route := &er.routes[i]
+		if route.method == method && route.matches(segments, er.values) && (best == nil || route.moreSpecific(best)) {
+			best = route
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	var params httprouter.Params
+	for i, segment := range best.segments {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, httprouter.Param{Key: strings.TrimPrefix(segment, ":"), Value: segments[i]})
+		}
+	}
+	return best.handler, params
+}
+
+// fixedPath is the path a request is redirected to when it only matches a route of the method once it's cleaned or
+// its trailing slash is removed, as the router redirects
+func (er *economicRoutes) fixedPath(method, path string) (string, bool) {
+	if method == http.MethodConnect {
+		return "", false
+	}
+	fixed := httprouter.CleanPath(path)
+	if len(fixed) > len(er.prefix) {
+		fixed = strings.TrimSuffix(fixed, "/")
+	}
+	if fixed == path || !strings.HasPrefix(fixed, er.prefix) {
+		return "", false
+	}
+	if handler, _ := er.match(method, strings.TrimPrefix(fixed, er.prefix)); handler == nil {
+		return "", false
+	}
+	return fixed, true
+}
+
+// allowed lists the methods of the routes matching the path, in the format of the Allow header, empty when there are
+// none
+func (er *economicRoutes) allowed(path string) string {
+	var allowed []string
+	for _, method := range economicMethods {
+		if method == http.MethodOptions {
+			continue
+		}
+		if handler, _ := er.match(method, path); handler != nil {
+			allowed = append(allowed, method)
+		}
+	}
+	if len(allowed) == 0 {
+		return ""
+	}
+	allowed = append(allowed, http.MethodOptions)
+	sort.Strings(allowed)
+	return strings.Join(allowed, ", ")
+}
+
+func (route *economicRoute) matches(segments []string, values map[string]map[string]bool) bool {
+	if len(segments) != len(route.segments) {
+		return false
+	}
+	for i, segment := range route.segments {
+		if segments[i] == "" {
+			return false
+		}
+		if !strings.HasPrefix(segment, ":") {
+			if segment != segments[i] {
+				return false
+			}
+		} else if allowed, ok := values[strings.TrimPrefix(segment, ":")]; ok && !allowed[segments[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+func (route *economicRoute) moreSpecific(other *economicRoute) bool {
+	for i, segment := range route.segments {
+		static, otherStatic := !strings.HasPrefix(segment, ":"), !strings.HasPrefix(other.segments[i], ":")
+		if static != otherStatic {
+			return static
+		}
+	}
+	return false
+}
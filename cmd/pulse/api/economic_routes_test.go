@@ -0,0 +1,95 @@
+package api
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEconomicRoutes(t *testing.T) {
+	methodNotAllowed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	routes := newEconomicRoutes("/v1/economic/", http.NotFoundHandler(), methodNotAllowed)
+	routes.restrict(slugParam, []string{"cpi", "reports"})
+	routes.add(http.MethodPost, "/v1/economic/batch", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Route", "/v1/economic/batch")
+	})
+	for _, path := range []string{
+		"/v1/economic/dashboard",
+		"/v1/economic/reports",
+		"/v1/economic/reports/:slug",
+		"/v1/economic/treasury_yield/curve",
+		"/v1/economic/treasury_yield/:maturity",
+		"/v1/economic/treasury_yield/:maturity/stats",
+		"/v1/economic/:slug",
+		"/v1/economic/:slug/stats",
+	} {
+		path := path
+		routes.add(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request) {
+			params := httprouter.ParamsFromContext(r.Context())
+			w.Header().Set("Route", path)
+			w.Header().Set("Slug", params.ByName(slugParam))
+			w.Header().Set("Maturity", params.ByName("maturity"))
+		})
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		route    string
+		slug     string
+		maturity string
+		// status is the status of a request which doesn't match a route
+		status   int
+		allow    string
+		location string
+	}{
+		{name: "Static", path: "/v1/economic/dashboard", route: "/v1/economic/dashboard"},
+		{name: "Static Before Slug", path: "/v1/economic/reports", route: "/v1/economic/reports"},
+		{name: "Static With Param", path: "/v1/economic/reports/cpi", route: "/v1/economic/reports/:slug", slug: "cpi"},
+		{name: "Static Before Maturity", path: "/v1/economic/treasury_yield/curve", route: "/v1/economic/treasury_yield/curve"},
+		{name: "Maturity", path: "/v1/economic/treasury_yield/10y", route: "/v1/economic/treasury_yield/:maturity", maturity: "10y"},
+		{name: "Maturity Before Slug", path: "/v1/economic/treasury_yield/10y/stats", route: "/v1/economic/treasury_yield/:maturity/stats", maturity: "10y"},
+		{name: "Slug", path: "/v1/economic/cpi", route: "/v1/economic/:slug", slug: "cpi"},
+		{name: "Slug Suffix", path: "/v1/economic/cpi/stats", route: "/v1/economic/:slug/stats", slug: "cpi"},
+		{name: "Post", method: http.MethodPost, path: "/v1/economic/batch", route: "/v1/economic/batch"},
+		{name: "Unknown Suffix", path: "/v1/economic/cpi/unknown", status: http.StatusNotFound},
+		{name: "Empty Slug", path: "/v1/economic/", status: http.StatusNotFound},
+		{name: "Unknown Path Other Method", method: http.MethodPost, path: "/v1/economic/cpi/unknown", status: http.StatusNotFound},
+		{name: "Other Method", method: http.MethodPost, path: "/v1/economic/cpi", status: http.StatusMethodNotAllowed, allow: "GET, OPTIONS"},
+		{name: "Options", method: http.MethodOptions, path: "/v1/economic/cpi/stats", status: http.StatusOK, allow: "GET, OPTIONS"},
+		{name: "Trailing Slash", path: "/v1/economic/cpi/", status: http.StatusMovedPermanently, location: "/v1/economic/cpi"},
+		{name: "Trailing Slash Query", path: "/v1/economic/cpi/?years=2", status: http.StatusMovedPermanently, location: "/v1/economic/cpi?years=2"},
+		{name: "Trailing Slash Other Method", method: http.MethodPost, path: "/v1/economic/batch/", status: http.StatusTemporaryRedirect, location: "/v1/economic/batch"},
+		{name: "Unclean Path", path: "/v1/economic//reports/../cpi/stats", status: http.StatusMovedPermanently, location: "/v1/economic/cpi/stats"},
+		{name: "Trailing Slash Unknown", path: "/v1/economic/cpi/unknown/", status: http.StatusNotFound},
+		{name: "Unknown Slug", path: "/v1/economic/unknown", status: http.StatusNotFound},
+		{name: "Unknown Slug Other Method", method: http.MethodPost, path: "/v1/economic/unknown", status: http.StatusNotFound},
+		{name: "Unknown Slug Suffix", path: "/v1/economic/unknown/stats", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rr := httptest.NewRecorder()
+			routes.ServeHTTP(rr, httptest.NewRequest(method, tt.path, nil))
+
+			if tt.route == "" {
+				assert.Equal(t, tt.status, rr.Code)
+				assert.Equal(t, tt.allow, rr.Header().Get("Allow"))
+				assert.Equal(t, tt.location, rr.Header().Get("Location"))
+				return
+			}
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.route, rr.Header().Get("Route"))
+			assert.Equal(t, tt.slug, rr.Header().Get("Slug"))
+			assert.Equal(t, tt.maturity, rr.Header().Get("Maturity"))
+		})
+	}
+}
@@ -1,14 +1,24 @@
 package api
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+)
+
+// dashboardMaxAge is how long clients and proxies may reuse the summary, the summary changes at most once a day as
+// the data sync runs daily
+const dashboardMaxAge = 60
 
 func (app *application) economicDashHandler(w http.ResponseWriter, r *http.Request) {
 	data, err := app.services.Economicdashservice.GetDashboardSummary()
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
+		return
 	}
 	env := envelope{
 		"economicSummaries": &data,
 	}
-	app.WriteJson(w, http.StatusOK, env, nil)
+	headers := make(http.Header)
+	headers.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", dashboardMaxAge))
+	app.WriteJson(w, http.StatusOK, env, headers)
 }
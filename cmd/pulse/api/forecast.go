@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+const (
+	horizonParam = "horizon"
+	modelParam   = "model"
+)
+
+func (app *application) forecastHandler(s data.Series) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			Model   data.ForecastModel
+			Horizon int
+		}
+
+		v := validator.New()
+
+		qs := r.URL.Query()
+		input.Model = data.ForecastModel(app.readString(qs, modelParam, string(data.ForecastETS)))
+		input.Horizon = app.readInt(qs, horizonParam, 12, v)
+
+		data.ValidateForecast(v, input.Model, input.Horizon)
+		if !v.Valid() {
+			app.failedValidationResponse(w, r, v.Errors)
+			return
+		}
+
+		forecast, err := app.services.ForecastService.Forecast(r.Context(), s.ReportType, input.Model, input.Horizon)
+		if err != nil {
+			switch {
+			case errors.Is(err, data.ErrNotEnoughData):
+				app.errorResponse(w, r, http.StatusUnprocessableEntity, "the series does not have enough data to forecast")
+			default:
+				utils.Logger(r.Context()).Error("forecastHandler error forecasting", zap.Error(err), zap.String("series", s.Slug))
+				app.serverErrorResponse(w, r, err)
+			}
+			return
+		}
+
+		err = app.WriteJson(w, http.StatusOK, envelope{"data": forecast}, nil)
+		if err != nil {
+			utils.Logger(r.Context()).Error("forecastHandler error writing json", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatParam = "format"
+
+	contentTypeJson   = "application/json"
+	contentTypeCsv    = "text/csv"
+	contentTypeNdjson = "application/x-ndjson"
+)
+
+type responseFormat string
+
+const (
+	formatJson   responseFormat = "json"
+	formatCsv    responseFormat = "csv"
+	formatNdjson responseFormat = "ndjson"
+)
+
+var economicWithChangeCsvHeader = []string{"date", "value", "change", "anomaly_score", "outlier"}
+
+// readFormat negotiates the response format, the format query param takes precedence over the Accept header
+func (app *application) readFormat(r *http.Request, v *validator.Validator) responseFormat {
+	switch f := responseFormat(strings.ToLower(r.URL.Query().Get(formatParam))); f {
+	case formatJson, formatCsv, formatNdjson:
+		return f
+	case "":
+	default:
+		v.AddError(formatParam, "must be one of json, csv or ndjson")
+		return formatJson
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.Split(accept, ";")[0])
+		switch strings.ToLower(mediaType) {
+		case contentTypeCsv:
+			return formatCsv
+		case contentTypeNdjson:
+			return formatNdjson
+		case contentTypeJson, "*/*":
+			return formatJson
+		}
+	}
+	return formatJson
+}
+
+// rowWriter writes the rows of a csv or ndjson response as they are read, so a page is never held in memory. The
+// paging metadata is sent in the response headers by start, before the first row
+type rowWriter[T any] struct {
+	w        http.ResponseWriter
+	format   responseFormat
+	filename string
+	header   []string
+	record   func(T) []string
+	csv      *csv.Writer
+	json     *json.Encoder
+}
+
+func economicWithChangeWriter(w http.ResponseWriter, format responseFormat, report data.ReportType) *rowWriter[data.EconomicWithChange] {
+	return &rowWriter[data.EconomicWithChange]{w: w, format: format, filename: report.ToTable(), header: economicWithChangeCsvHeader, record: func(e data.EconomicWithChange) []string {
+		change, score, outlier := "", "", ""
+		if e.Change.Valid {
+			change = e.Change.Decimal.String()
+		}
+		if e.Anomaly != nil {
+			score = e.Anomaly.Score.String()
+			outlier = strconv.FormatBool(e.Anomaly.Outlier)
+		}
+		return []string{e.Date.Format(dateLayout), e.Value.String(), change, score, outlier}
+	}}
+}
+
+// economicStatsWriter writes a CSV column per requested metric, in the order they were requested
+func economicStatsWriter(w http.ResponseWriter, format responseFormat, report data.ReportType, metrics data.StatsMetrics) *rowWriter[data.EconomicStats] {
+	header := append([]string{"from", "to"}, metrics.Strings()...)
+	return &rowWriter[data.EconomicStats]{w: w, format: format, filename: report.ToTable() + "_stats", header: header, record: func(e data.EconomicStats) []string {
+		record := []string{e.StartDate.Format(dateLayout), e.EndDate.Format(dateLayout)}
+		for _, metric := range metrics {
+			value := ""
+			if m := e.Metric(metric); m != nil {
+				value = m.String()
+			}
+			record = append(record, value)
+		}
+		return record
+	}}
+}
+
+// start writes the headers of the response, and the header row of a CSV
+func (rw *rowWriter[T]) start(meta data.Metadata) error {
+	setMetadataHeaders(rw.w, &meta)
+	if rw.format == formatCsv {
+		rw.w.Header().Set("Content-Type", contentTypeCsv)
+		rw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", rw.filename+".csv"))
+		rw.w.WriteHeader(http.StatusOK)
+		rw.csv = csv.NewWriter(rw.w)
+		return rw.csv.Write(rw.header)
+	}
+	rw.w.Header().Set("Content-Type", contentTypeNdjson)
+	rw.w.WriteHeader(http.StatusOK)
+	rw.json = json.NewEncoder(rw.w)
+	return nil
+}
+
+// write writes a row as a CSV record, or as a JSON document on its own line
+func (rw *rowWriter[T]) write(row T) error {
+	if rw.csv != nil {
+		return rw.csv.Write(rw.record(row))
+	}
+	return rw.json.Encode(row)
+}
+
+// finish flushes the records the CSV writer has buffered. An error reading the rows before the response was started is
+// sent as a server error, after it the response is on its way so the error is only logged
+func (rw *rowWriter[T]) finish(app *application, r *http.Request, caller string, err error) {
+	if err == nil && rw.csv != nil {
+		rw.csv.Flush()
+		err = rw.csv.Error()
+	}
+	if err == nil {
+		return
+	}
+	if rw.csv != nil || rw.json != nil {
+		utils.Logger(r.Context()).Error(caller+" error writing "+string(rw.format), zap.Error(err))
+		return
+	}
+	utils.Logger(r.Context()).Error(caller+" error getting data", zap.Error(err))
+	app.serverErrorResponse(rw.w, r, err)
+}
+
+func setMetadataHeaders(w http.ResponseWriter, meta *data.Metadata) {
+	if meta == nil {
+		return
+	}
+	w.Header().Set("X-Current-Page", strconv.Itoa(meta.CurrentPage))
+	w.Header().Set("X-Page-Size", strconv.Itoa(meta.PageSize))
+	w.Header().Set("X-First-Page", strconv.Itoa(meta.FirstPage))
+	w.Header().Set("X-Last-Page", strconv.Itoa(meta.LastPage))
+	w.Header().Set("X-Total-Records", strconv.Itoa(meta.TotalRecords))
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/services"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type mockEconomicService struct {
+	services.EconomicService
+	mock.Mock
+}
+
+func (m *mockEconomicService) EachPageWithPercentChange(ctx context.Context, reportType data.ReportType, filter data.SeriesFilter, paging data.Paging, start func(meta data.Metadata) error, fn func(economic data.EconomicWithChange) error) error {
+	args := m.Called(ctx, reportType, filter, paging, start, fn)
+	return args.Error(0)
+}
+
+// TestStreamEconomicData checks the rows are written as the service reads them, after the paging headers
+func TestStreamEconomicData(t *testing.T) {
+	date := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []data.EconomicWithChange{
+		{Date: date, Value: decimal.NewFromInt(110), Change: decimal.NewNullDecimal(decimal.NewFromInt(10))},
+		{Date: date.AddDate(0, -1, 0), Value: decimal.NewFromInt(100)},
+	}
+	paging := data.Paging{Page: 1, PageSize: 2}
+
+	tests := []struct {
+		name   string
+		format responseFormat
+		rows   []data.EconomicWithChange
+		err    error
+		// errAfter is the number of rows written before the service fails
+		errAfter    int
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "CSV",
+			format:      formatCsv,
+			rows:        rows,
+			status:      http.StatusOK,
+			contentType: contentTypeCsv,
+			body:        "date,value,change,anomaly_score,outlier\n2022-01-01,110,10,,\n2021-12-01,100,,,\n",
+		},
+		{
+			name:        "NDJSON",
+			format:      formatNdjson,
+			rows:        rows,
+			status:      http.StatusOK,
+			contentType: contentTypeNdjson,
+			body:        "{\"date\":\"2022-01-01T00:00:00Z\",\"value\":\"110\",\"change\":\"10\"}\n{\"date\":\"2021-12-01T00:00:00Z\",\"value\":\"100\",\"change\":null}\n",
+		},
+		{
+			name:        "Empty Page",
+			format:      formatCsv,
+			status:      http.StatusOK,
+			contentType: contentTypeCsv,
+			body:        "date,value,change,anomaly_score,outlier\n",
+		},
+		{
+			name:     "Error Before Rows",
+			format:   formatCsv,
+			rows:     rows,
+			err:      errors.New("timeout"),
+			errAfter: -1,
+			status:   http.StatusInternalServerError,
+		},
+		{
+			name:        "Error After Rows",
+			format:      formatNdjson,
+			rows:        rows,
+			err:         errors.New("timeout"),
+			errAfter:    1,
+			status:      http.StatusOK,
+			contentType: contentTypeNdjson,
+			body:        "{\"date\":\"2022-01-01T00:00:00Z\",\"value\":\"110\",\"change\":\"10\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			economic := new(mockEconomicService)
+			economic.On("EachPageWithPercentChange", mock.Anything, data.CPI, mock.Anything, paging, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					start := args.Get(4).(func(data.Metadata) error)
+					fn := args.Get(5).(func(data.EconomicWithChange) error)
+					if tt.errAfter < 0 {
+						return
+					}
+					assert.NoError(t, start(data.CalculateMetadata(len(tt.rows), paging.Page, paging.PageSize)))
+					for i, row := range tt.rows {
+						if tt.err != nil && i == tt.errAfter {
+							return
+						}
+						// the headers are sent before the first row
+						assert.Equal(t, http.StatusOK, rr.Code)
+						assert.NoError(t, fn(row))
+					}
+				}).
+				Return(tt.err)
+			app := &application{services: services.ServicesModel{AlphaVantageEconomicService: economic}}
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/economic/cpi", nil)
+			streamEconomicData(r.Context(), app, data.CPI, tt.format, data.SeriesFilter{}, paging, rr, r)
+
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.status != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.contentType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, strconv.Itoa(len(tt.rows)), rr.Result().Header.Get("X-Total-Records"))
+			assert.Equal(t, tt.body, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// graphqlMaxDepth is the deepest a field can be selected, Query.series.observations.items.anomaly.score is 5
+	graphqlMaxDepth = 8
+	// graphqlMaxComplexity is the most fields a query can resolve, counting the fields under a paged field once for
+	// each item of the page
+	graphqlMaxComplexity = 5000
+)
+
+// graphqlListSizes are the items the list fields resolve to, the size of a paged field is its default page size
+var graphqlListSizes = map[string]int{
+	"reports":      len(data.ReportTypes()),
+	"dashboard":    len(data.ReportTypes()),
+	"observations": 12,
+	"stats":        12,
+}
+
+// graphqlHandler executes a query against the schema after checking its depth and complexity
+func (app *application) graphqlHandler(schema graphql.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			Query         string                 `json:"query"`
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+		}
+
+		err := app.ReadJson(w, r, &input)
+		if err != nil {
+			app.badRequestResponse(w, r)
+			return
+		}
+
+		// A query which doesn't parse is left for graphql.Do to report with the location of the syntax error
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(input.Query)})})
+		if err == nil {
+			limits := queryLimits{doc: doc, variables: input.Variables}
+			if msg := limits.check(input.OperationName); msg != "" {
+				app.failedValidationResponse(w, r, map[string]string{"query": msg})
+				return
+			}
+		}
+
+		res := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  input.Query,
+			OperationName:  input.OperationName,
+			VariableValues: input.Variables,
+			Context:        r.Context(),
+		})
+		if res.HasErrors() {
+			utils.Logger(r.Context()).Info("graphqlHandler query has errors", zap.Any("errors", res.Errors))
+		}
+
+		env := envelope{"data": res.Data}
+		if res.HasErrors() {
+			env["errors"] = res.Errors
+		}
+		err = app.WriteJson(w, http.StatusOK, env, nil)
+		if err != nil {
+			utils.Logger(r.Context()).Error("graphqlHandler error writing json", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+		}
+	}
+}
+
+// queryLimits measures the depth and complexity of the operation of a query, following fragment spreads. Introspection
+// fields are skipped as the nested type references of an introspection query are deeper than any data query
+type queryLimits struct {
+	doc       *ast.Document
+	variables map[string]interface{}
+}
+
+// check returns why the operation is over the limits, or an empty string when it is within them
+func (l queryLimits) check(operationName string) string {
+	for _, def := range l.doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok || (operationName != "" && (op.Name == nil || op.Name.Value != operationName)) {
+			continue
+		}
+		depth, complexity := l.measure(op.SelectionSet, 1, map[string]bool{})
+		if depth > graphqlMaxDepth {
+			return fmt.Sprintf("must not be deeper than %d fields, the query is %d deep", graphqlMaxDepth, depth)
+		}
+		if complexity > graphqlMaxComplexity {
+			return fmt.Sprintf("must not have a complexity over %d, the query has a complexity of %d", graphqlMaxComplexity, complexity)
+		}
+	}
+	return ""
+}
+
+// measure returns the depth and complexity of the selections, visited holds the fragments being spread on the path to
+// the selections so a fragment cycle doesn't recurse forever, the validation of the query reports the cycle
+func (l queryLimits) measure(set *ast.SelectionSet, depth int, visited map[string]bool) (int, int) {
+	if set == nil {
+		return depth - 1, 0
+	}
+
+	maxDepth, complexity := depth, 0
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			d, c = l.measure(selection.SelectionSet, depth+1, visited)
+			c = 1 + l.multiplier(selection)*c
+		case *ast.InlineFragment:
+			d, c = l.measure(selection.SelectionSet, depth, visited)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment := l.fragment(name)
+			if fragment == nil || visited[name] {
+				continue
+			}
+			visited[name] = true
+			d, c = l.measure(fragment.SelectionSet, depth, visited)
+			delete(visited, name)
+		}
+		if d > maxDepth {
+			maxDepth = d
+		}
+		complexity += c
+	}
+	return maxDepth, complexity
+}
+
+// multiplier is the number of items of a list field, the fields under it are resolved for each item
+func (l queryLimits) multiplier(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != pageSizeParam {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			// json numbers decode to float64
+			if n, ok := l.variables[value.Name.Value].(float64); ok && n > 0 {
+				return int(n)
+			}
+		}
+	}
+	if size, ok := graphqlListSizes[field.Name.Value]; ok {
+		return size
+	}
+	return 1
+}
+
+func (l queryLimits) fragment(name string) *ast.FragmentDefinition {
+	for _, def := range l.doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name.Value == name {
+			return fragment
+		}
+	}
+	return nil
+}
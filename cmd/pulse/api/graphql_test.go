@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func parseQueryLimits(t *testing.T, query string, variables map[string]interface{}) queryLimits {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query)})})
+	require.NoError(t, err)
+	return queryLimits{doc: doc, variables: variables}
+}
+
+func TestQueryLimits_Measure(t *testing.T) {
+	reports := graphqlListSizes["reports"]
+
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]interface{}
+		depth      int
+		complexity int
+	}{
+		{
+			name:       "Nested Depth",
+			query:      `{ a { b { c { d } } } }`,
+			depth:      4,
+			complexity: 4,
+		},
+		{
+			name:       "Default Page Size",
+			query:      `{ series(slug: "cpi") { observations { items { date value } } } }`,
+			depth:      4,
+			complexity: 1 + (1 + 12*(1+2)),
+		},
+		{
+			name:       "Literal Page Size",
+			query:      `{ series(slug: "cpi") { observations(pageSize: 50) { items { date value } } } }`,
+			depth:      4,
+			complexity: 1 + (1 + 50*(1+2)),
+		},
+		{
+			name:       "Variable Page Size",
+			query:      `query Series($size: Int) { series(slug: "cpi") { observations(pageSize: $size) { items { date value } } } }`,
+			variables:  map[string]interface{}{"size": float64(100)},
+			depth:      4,
+			complexity: 1 + (1 + 100*(1+2)),
+		},
+		{
+			name:       "Missing Variable Page Size",
+			query:      `query Series($size: Int) { series(slug: "cpi") { observations(pageSize: $size) { items { date value } } } }`,
+			depth:      4,
+			complexity: 1 + (1 + 12*(1+2)),
+		},
+		{
+			name:       "Negative Page Size",
+			query:      `{ series(slug: "cpi") { observations(pageSize: -1) { items { date value } } } }`,
+			depth:      4,
+			complexity: 1 + (1 + 12*(1+2)),
+		},
+		{
+			name:       "Aliases",
+			query:      `{ first: reports { slug } second: reports { slug name } }`,
+			depth:      2,
+			complexity: (1 + reports) + (1 + reports*2),
+		},
+		{
+			name:       "Fragment",
+			query:      `{ ...Reports } fragment Reports on Query { reports { slug } }`,
+			depth:      2,
+			complexity: 1 + reports,
+		},
+		{
+			name:       "Inline Fragment",
+			query:      `{ ... on Query { reports { slug } } }`,
+			depth:      2,
+			complexity: 1 + reports,
+		},
+		{
+			name:       "Fragment Cycle",
+			query:      `{ reports { ...A } } fragment A on Report { slug ...B } fragment B on Report { name ...A }`,
+			depth:      2,
+			complexity: 1 + reports*2,
+		},
+		{
+			name:       "Unknown Fragment",
+			query:      `{ reports { slug ...Missing } }`,
+			depth:      2,
+			complexity: 1 + reports,
+		},
+		{
+			name:       "Introspection Skipped",
+			query:      `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { name } } } } } } } } reports { slug } }`,
+			depth:      2,
+			complexity: 1 + reports,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := parseQueryLimits(t, tt.query, tt.variables)
+			op := limits.doc.Definitions[0].(*ast.OperationDefinition)
+
+			depth, complexity := limits.measure(op.SelectionSet, 1, map[string]bool{})
+
+			assert.Equal(t, tt.depth, depth)
+			assert.Equal(t, tt.complexity, complexity)
+		})
+	}
+}
+
+func TestQueryLimits_Check(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		variables     map[string]interface{}
+		operationName string
+		want          string
+	}{
+		{
+			name:  "Within Limits",
+			query: `{ series(slug: "cpi") { observations(pageSize: 100) { items { date value anomaly { score } } } } }`,
+		},
+		{
+			name:  "Too Deep",
+			query: `{ a { b { c { d { e { f { g { h { i } } } } } } } } }`,
+			want:  "must not be deeper than 8 fields, the query is 9 deep",
+		},
+		{
+			name:  "Too Complex",
+			query: `{ series(slug: "cpi") { observations(pageSize: 1000) { items { date value anomaly { score } } } } }`,
+			want:  "must not have a complexity over 5000, the query has a complexity of 5002",
+		},
+		{
+			name:      "Too Complex Variable",
+			query:     `query Series($size: Int) { series(slug: "cpi") { observations(pageSize: $size) { items { date } } } }`,
+			variables: map[string]interface{}{"size": float64(5000)},
+			want:      "must not have a complexity over 5000, the query has a complexity of 10002",
+		},
+		{
+			name:  "Deep Introspection",
+			query: `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } }`,
+		},
+		{
+			name:          "Named Operation",
+			query:         `query Small { reports { slug } } query Deep { a { b { c { d { e { f { g { h { i } } } } } } } } }`,
+			operationName: "Small",
+		},
+		{
+			name:          "Other Named Operation",
+			query:         `query Small { reports { slug } } query Deep { a { b { c { d { e { f { g { h { i } } } } } } } } }`,
+			operationName: "Deep",
+			want:          "must not be deeper than 8 fields, the query is 9 deep",
+		},
+		{
+			name:  "Every Operation Without A Name",
+			query: `query Small { reports { slug } } query Deep { a { b { c { d { e { f { g { h { i } } } } } } } } }`,
+			want:  "must not be deeper than 8 fields, the query is 9 deep",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := parseQueryLimits(t, tt.query, tt.variables)
+
+			assert.Equal(t, tt.want, limits.check(tt.operationName))
+		})
+	}
+}
+
+func TestGraphQL_Me(t *testing.T) {
+	app := &application{registry: data.NewSeriesRegistry(nil)}
+	schema, err := app.newGraphQLSchema()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		user *data.User
+		want map[string]interface{}
+		err  string
+	}{
+		{name: "Authenticated", user: &data.User{ID: 1, Name: "Pulse", Email: "pulse@example.com"}, want: map[string]interface{}{"me": map[string]interface{}{"id": "1", "email": "pulse@example.com"}}},
+		{name: "Anonymous", user: data.AnonymousUser, err: "you must be authenticated"},
+		{name: "No User", err: "you must be authenticated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, userContextKey, tt.user)
+			}
+
+			res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ me { id email } }`, Context: ctx})
+
+			if tt.err == "" {
+				assert.Empty(t, res.Errors)
+				assert.Equal(t, tt.want, res.Data)
+				return
+			}
+			require.Len(t, res.Errors, 1)
+			assert.Equal(t, tt.err, res.Errors[0].Message)
+			assert.Nil(t, res.Data)
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"sort"
+	"strings"
+	"time"
+)
+
+// graphqlSeries is the source of the Series type, the date range is shared by its observations and stats
+type graphqlSeries struct {
+	series    data.Series
+	dateRange data.DateRange
+}
+
+// decimalScalar serialises decimals as strings, the same as the json of the REST endpoints, so no precision is lost
+var decimalScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Decimal",
+	Description: "A decimal number serialised as a string",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case decimal.Decimal:
+			return value.String()
+		case *decimal.Decimal:
+			if value == nil {
+				return nil
+			}
+			return value.String()
+		case decimal.NullDecimal:
+			if !value.Valid {
+				return nil
+			}
+			return value.Decimal.String()
+		}
+		return nil
+	},
+})
+
+var coverageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Coverage",
+	Fields: graphql.Fields{
+		"from":         &graphql.Field{Type: graphql.DateTime},
+		"to":           &graphql.Field{Type: graphql.DateTime},
+		"observations": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+var reportType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Report",
+	Fields: graphql.Fields{
+		"slug":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"displayName":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"unit":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"frequency":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"image":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"coverage":     &graphql.Field{Type: graphql.NewNonNull(coverageType)},
+		"lastSyncDate": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+	},
+})
+
+var metadataType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Metadata",
+	Fields: graphql.Fields{
+		"currentPage":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: metadataField(func(m data.Metadata) int { return m.CurrentPage })},
+		"pageSize":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: metadataField(func(m data.Metadata) int { return m.PageSize })},
+		"firstPage":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: metadataField(func(m data.Metadata) int { return m.FirstPage })},
+		"lastPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: metadataField(func(m data.Metadata) int { return m.LastPage })},
+		"totalRecords": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: metadataField(func(m data.Metadata) int { return m.TotalRecords })},
+	},
+})
+
+var anomalyScoreType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AnomalyScore",
+	Fields: graphql.Fields{
+		"score":         &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"seasonalScore": &graphql.Field{Type: decimalScalar},
+		"outlier":       &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+	},
+})
+
+var observationType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Observation",
+	Fields: graphql.Fields{
+		"date":    &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"value":   &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"change":  &graphql.Field{Type: decimalScalar},
+		"anomaly": &graphql.Field{Type: anomalyScoreType},
+	},
+})
+
+var observationPageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ObservationPage",
+	Fields: graphql.Fields{
+		"items": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(observationType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return *p.Source.(*data.EconomicWithChangeResult).Data, nil
+			},
+		},
+		"meta": &graphql.Field{
+			Type: graphql.NewNonNull(metadataType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*data.EconomicWithChangeResult).Meta, nil
+			},
+		},
+	},
+})
+
+var statsBucketType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StatsBucket",
+	Fields: func() graphql.Fields {
+		fields := graphql.Fields{
+			"from": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"to":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"count": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(data.EconomicStats).Count, nil
+				},
+			},
+		}
+		for _, metric := range data.AllStatsMetrics {
+			if metric == data.StatsCount {
+				continue
+			}
+			metric := metric
+			fields[string(metric)] = &graphql.Field{
+				Type: decimalScalar,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(data.EconomicStats).Metric(metric), nil
+				},
+			}
+		}
+		return fields
+	}(),
+})
+
+var statsPageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StatsPage",
+	Fields: graphql.Fields{
+		"items": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(statsBucketType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return *p.Source.(*data.EconomicStatsResult).Data, nil
+			},
+		},
+		"meta": &graphql.Field{
+			Type: graphql.NewNonNull(metadataType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*data.EconomicStatsResult).Meta, nil
+			},
+		},
+	},
+})
+
+var summaryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DashboardSummary",
+	Fields: graphql.Fields{
+		"slug":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"name":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"lastUpdate": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"value":      &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"change":     &graphql.Field{Type: decimalScalar},
+		"maturity": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if maturity, ok := p.Source.(data.Summary).Extras["maturity"]; ok {
+					return fmt.Sprint(maturity), nil
+				}
+				return nil, nil
+			},
+		},
+	},
+})
+
+// newGraphQLSchema builds the schema of the graphql endpoint, the resolvers call the same services as the REST handlers
+func (app *application) newGraphQLSchema() (graphql.Schema, error) {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"activated": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*data.User).CreatedAt, nil
+				},
+			},
+			"permissions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					permissions, err := app.services.PermissionsService.GetAllForUser(p.Context, p.Source.(*data.User).ID)
+					if err != nil {
+						return nil, errors.Wrap(err, "error getting the permissions of the user")
+					}
+					return []string(permissions), nil
+				},
+			},
+		},
+	})
+
+	series := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Series",
+		Fields: graphql.Fields{
+			"slug": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(graphqlSeries).series.Slug, nil
+				},
+			},
+			"report": &graphql.Field{
+				Type:    reportType,
+				Resolve: app.resolveSeriesReport,
+			},
+			"observations": &graphql.Field{
+				Type: graphql.NewNonNull(observationPageType),
+				Args: graphql.FieldConfigArgument{
+					pageParam:     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					pageSizeParam: &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 12},
+					changeParam:   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: string(data.DefaultChange.Type)},
+					lagParam:      &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: data.DefaultChange.Lag},
+				},
+				Resolve: app.resolveObservations,
+			},
+			"stats": &graphql.Field{
+				Type: graphql.NewNonNull(statsPageType),
+				Args: graphql.FieldConfigArgument{
+					timeBucketDaysParam: &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 365},
+					metricsParam:        &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					pageParam:           &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					pageSizeParam:       &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 12},
+				},
+				Resolve: app.resolveStats,
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type: graphql.NewNonNull(user),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return graphqlUser(p.Context)
+				},
+			},
+			"reports": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(reportType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					reports, err := app.services.CatalogService.Reports(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					return *reports, nil
+				},
+			},
+			"report": &graphql.Field{
+				Type: reportType,
+				Args: graphql.FieldConfigArgument{
+					"slug": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					s, ok := app.registry.Lookup(p.Args["slug"].(string))
+					if !ok {
+						return nil, nil
+					}
+					return app.resolveReport(p.Context, s)
+				},
+			},
+			"series": &graphql.Field{
+				Type: series,
+				Args: graphql.FieldConfigArgument{
+					"slug":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					yearsParam: &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					fromParam:  &graphql.ArgumentConfig{Type: graphql.String},
+					toParam:    &graphql.ArgumentConfig{Type: graphql.String},
+					asOfParam:  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: app.resolveSeries,
+			},
+			"dashboard": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(summaryType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					summaries, err := app.services.Economicdashservice.GetDashboardSummary()
+					if err != nil {
+						return nil, err
+					}
+					return *summaries, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// resolveSeries resolves the slug through the registry, so aliases work too, and reads the date range the same way as
+// the query params of the REST endpoints. Unknown slugs resolve to null
+func (app *application) resolveSeries(p graphql.ResolveParams) (interface{}, error) {
+	s, ok := app.registry.Lookup(p.Args["slug"].(string))
+	if !ok {
+		return nil, nil
+	}
+
+	v := validator.New()
+	now := time.Now()
+	var dateRange data.DateRange
+	if p.Args[fromParam] == nil && p.Args[toParam] == nil {
+		years := p.Args[yearsParam].(int)
+		checkYears(years, data.Unknown, v)
+		dateRange = data.DateRangeFromYears(years, now)
+	} else {
+		dateRange = data.DateRange{
+			From: graphqlDate(p.Args, fromParam, time.Time{}, v),
+			To:   graphqlDate(p.Args, toParam, now, v),
+		}
+	}
+	dateRange.AsOf = graphqlDate(p.Args, asOfParam, time.Time{}, v)
+
+	data.ValidateDateRange(v, dateRange)
+	if !v.Valid() {
+		return nil, validationError(v)
+	}
+	return graphqlSeries{series: s, dateRange: dateRange}, nil
+}
+
+func (app *application) resolveSeriesReport(p graphql.ResolveParams) (interface{}, error) {
+	return app.resolveReport(p.Context, p.Source.(graphqlSeries).series)
+}
+
+// resolveReport gets the catalog entry of the series, null when the report hasn't been loaded yet
+func (app *application) resolveReport(ctx context.Context, s data.Series) (interface{}, error) {
+	report, err := app.services.CatalogService.Report(ctx, s.Slug)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func (app *application) resolveObservations(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(graphqlSeries)
+	filter := data.SeriesFilter{
+		DateRange:   source.dateRange,
+		Frequency:   data.FrequencyNative,
+		Aggregation: data.AggregationLast,
+		Change: data.Change{
+			Type: data.ChangeType(p.Args[changeParam].(string)),
+			Lag:  p.Args[lagParam].(int),
+		},
+	}
+	paging := data.Paging{Page: p.Args[pageParam].(int), PageSize: p.Args[pageSizeParam].(int)}
+
+	v := validator.New()
+	data.ValidatePaging(v, paging)
+	data.ValidateSeriesFilter(v, filter)
+	if !v.Valid() {
+		return nil, validationError(v)
+	}
+
+	res, err := app.seriesPage(p.Context, source.series.ReportType, filter, paging)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (app *application) resolveStats(p graphql.ResolveParams) (interface{}, error) {
+	source := p.Source.(graphqlSeries)
+	timeBucketDays := p.Args[timeBucketDaysParam].(int)
+	metrics := data.DefaultStatsMetrics
+	if list, ok := p.Args[metricsParam].([]interface{}); ok {
+		metrics = make(data.StatsMetrics, 0, len(list))
+		for _, metric := range list {
+			metrics = append(metrics, data.StatsMetric(metric.(string)))
+		}
+	}
+	paging := data.Paging{Page: p.Args[pageParam].(int), PageSize: p.Args[pageSizeParam].(int)}
+
+	v := validator.New()
+	v.Check(timeBucketDays > 0, timeBucketDaysParam, "must be greater than zero")
+	data.ValidatePaging(v, paging)
+	data.ValidateStatsMetrics(v, metrics)
+	if !v.Valid() {
+		return nil, validationError(v)
+	}
+
+	res, err := app.seriesStats(p.Context, source.series.ReportType, source.dateRange, timeBucketDays, metrics, paging)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func metadataField(field func(data.Metadata) int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return field(*p.Source.(*data.Metadata)), nil
+	}
+}
+
+// graphqlUser is the authenticated user of the request, an error of the query when there is none
+func graphqlUser(ctx context.Context) (*data.User, error) {
+	u, ok := ctx.Value(userContextKey).(*data.User)
+	if !ok || u == data.AnonymousUser {
+		return nil, errors.New("you must be authenticated")
+	}
+	return u, nil
+}
+
+func graphqlDate(args map[string]interface{}, key string, defaultValue time.Time, v *validator.Validator) time.Time {
+	s, ok := args[key].(string)
+	if !ok {
+		return defaultValue
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		v.AddError(key, "must be a date in the format YYYY-MM-DD")
+		return defaultValue
+	}
+	return t
+}
+
+// validationError reports the failed checks of the validator as a single graphql error, ordered by key
+func validationError(v *validator.Validator) error {
+	keys := make([]string, 0, len(v.Errors))
+	for key := range v.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	msgs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s %s", key, v.Errors[key]))
+	}
+	return errors.New(strings.Join(msgs, ", "))
+}
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/mhamm84/pulse-api/proto/pulsepb"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"strings"
+	"time"
+)
+
+// economicServer implements the gRPC EconomicService over the same services as the REST handlers
+type economicServer struct {
+	pulsepb.UnimplementedEconomicServiceServer
+	app *application
+}
+
+// serveGRPC starts the gRPC server on its own port, it is stopped by the shutdown of serve
+func (app *application) serveGRPC() (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", app.cfg.GrpcPort))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listening for grpc")
+	}
+
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(app.grpcUnaryAuthenticate),
+		grpc.StreamInterceptor(app.grpcStreamAuthenticate),
+	)
+	pulsepb.RegisterEconomicServiceServer(srv, economicServer{app: app})
+
+	go func() {
+		utils.Logger(context.TODO()).Info("starting Pulse gRPC server", zap.String("addr", lis.Addr().String()))
+		if err := srv.Serve(lis); err != nil {
+			utils.Logger(context.TODO()).Error("error serving grpc", zap.Error(err))
+		}
+	}()
+	return srv, nil
+}
+
+func (app *application) grpcUnaryAuthenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := app.grpcAuthenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (app *application) grpcStreamAuthenticate(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := app.grpcAuthenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream carries the context with the authenticated user to the stream handler
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcAuthenticate checks the bearer token of the authorization metadata the same way as the authenticate and
+// requirePermissions middleware, returning the context with the request id and the user
+func (app *application) grpcAuthenticate(ctx context.Context, method string) (context.Context, error) {
+	ctx = utils.WithReqId(ctx, uuid.New().String())
+	utils.Logger(ctx).Debug("Incoming grpc request", zap.String("method", method))
+
+	var header string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(strings.ToLower(AuthorizationHeader)); len(values) > 0 {
+		header = values[0]
+	}
+
+	user, err := app.authenticateToken(ctx, header)
+	if err != nil {
+		switch {
+		case errors.Is(err, errInvalidAuthenticationToken):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		default:
+			return nil, grpcServerError(ctx, err)
+		}
+	}
+	if user == data.AnonymousUser {
+		return nil, status.Error(codes.Unauthenticated, "you must be authenticated to access this resource")
+	}
+	if !user.Activated {
+		return nil, status.Error(codes.PermissionDenied, "your user account must be activated to access this resource")
+	}
+
+	permitted, err := app.permitted(ctx, user, economicPermission)
+	if err != nil {
+		return nil, grpcServerError(ctx, err)
+	}
+	if !permitted {
+		return nil, status.Error(codes.PermissionDenied, "your user account doesn't have the necessary permissions to access this resource")
+	}
+
+	return context.WithValue(ctx, userContextKey, user), nil
+}
+
+func (s economicServer) ListReports(ctx context.Context, req *pulsepb.ListReportsRequest) (*pulsepb.ListReportsResponse, error) {
+	reports, err := s.app.services.CatalogService.Reports(ctx)
+	if err != nil {
+		return nil, grpcServerError(ctx, err)
+	}
+
+	res := &pulsepb.ListReportsResponse{Reports: make([]*pulsepb.Report, 0, len(*reports))}
+	for _, report := range *reports {
+		res.Reports = append(res.Reports, &pulsepb.Report{
+			Slug:        report.Slug,
+			DisplayName: report.DisplayName,
+			Description: report.Description,
+			Unit:        report.Unit,
+			Frequency:   report.Frequency,
+			Image:       report.Image,
+			Coverage: &pulsepb.Coverage{
+				From:         grpcTimestamp(report.Coverage.From),
+				To:           grpcTimestamp(report.Coverage.To),
+				Observations: int64(report.Coverage.Observations),
+			},
+			LastSyncDate: timestamppb.New(report.LastSyncDate),
+		})
+	}
+	return res, nil
+}
+
+// GetSeries streams the observations of the date range as they are read from a single query, so the client doesn't page
+// through the series itself
+func (s economicServer) GetSeries(req *pulsepb.GetSeriesRequest, stream pulsepb.EconomicService_GetSeriesServer) error {
+	ctx := stream.Context()
+	series, ok := s.app.registry.Lookup(req.GetSlug())
+	if !ok {
+		return status.Errorf(codes.NotFound, "unknown series %q", req.GetSlug())
+	}
+
+	v := validator.New()
+	filter := data.SeriesFilter{
+		DateRange:   grpcDateRange(req.GetDateRange(), v),
+		Frequency:   data.FrequencyNative,
+		Aggregation: data.AggregationLast,
+		Change:      data.DefaultChange,
+	}
+	if req.GetChange() != "" {
+		filter.Change.Type = data.ChangeType(req.GetChange())
+	}
+	if req.GetLag() != 0 {
+		filter.Change.Lag = int(req.GetLag())
+	}
+	data.ValidateSeriesFilter(v, filter)
+	if !v.Valid() {
+		return status.Error(codes.InvalidArgument, validationError(v).Error())
+	}
+
+	// An error sending an observation is the stream's, returned as it is rather than as a server error
+	var sendErr error
+	err := s.app.services.AlphaVantageEconomicService.EachWithPercentChange(ctx, series.ReportType, filter, func(observation data.EconomicWithChange) error {
+		o := &pulsepb.Observation{
+			Date:   timestamppb.New(observation.Date),
+			Value:  observation.Value.String(),
+			Change: grpcNullDecimal(observation.Change),
+		}
+		if observation.Anomaly != nil {
+			o.Anomaly = &pulsepb.AnomalyScore{
+				Score:         observation.Anomaly.Score.String(),
+				SeasonalScore: grpcNullDecimal(observation.Anomaly.SeasonalScore),
+				Outlier:       observation.Anomaly.Outlier,
+			}
+		}
+		sendErr = stream.Send(o)
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return grpcServerError(ctx, err)
+	}
+	return nil
+}
+
+func (s economicServer) GetStats(ctx context.Context, req *pulsepb.GetStatsRequest) (*pulsepb.GetStatsResponse, error) {
+	series, ok := s.app.registry.Lookup(req.GetSlug())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown series %q", req.GetSlug())
+	}
+
+	v := validator.New()
+	dateRange := grpcDateRange(req.GetDateRange(), v)
+	timeBucketDays := grpcInt(req.GetTimeBucketDays(), 365)
+	metrics := data.DefaultStatsMetrics
+	if len(req.GetMetrics()) > 0 {
+		metrics = make(data.StatsMetrics, 0, len(req.GetMetrics()))
+		for _, metric := range req.GetMetrics() {
+			metrics = append(metrics, data.StatsMetric(metric))
+		}
+	}
+	paging := data.Paging{Page: grpcInt(req.GetPage(), 1), PageSize: grpcInt(req.GetPageSize(), 12)}
+
+	v.Check(timeBucketDays > 0, timeBucketDaysParam, "must be greater than zero")
+	data.ValidatePaging(v, paging)
+	data.ValidateDateRange(v, dateRange)
+	data.ValidateStatsMetrics(v, metrics)
+	if !v.Valid() {
+		return nil, status.Error(codes.InvalidArgument, validationError(v).Error())
+	}
+
+	stats, err := s.app.seriesStats(ctx, series.ReportType, dateRange, timeBucketDays, metrics, paging)
+	if err != nil {
+		return nil, grpcServerError(ctx, err)
+	}
+
+	res := &pulsepb.GetStatsResponse{
+		Buckets: make([]*pulsepb.StatsBucket, 0, len(*stats.Data)),
+		Meta: &pulsepb.Metadata{
+			CurrentPage:  int32(stats.Meta.CurrentPage),
+			PageSize:     int32(stats.Meta.PageSize),
+			FirstPage:    int32(stats.Meta.FirstPage),
+			LastPage:     int32(stats.Meta.LastPage),
+			TotalRecords: int32(stats.Meta.TotalRecords),
+		},
+	}
+	for _, bucket := range *stats.Data {
+		b := &pulsepb.StatsBucket{
+			From:    timestamppb.New(bucket.StartDate),
+			To:      timestamppb.New(bucket.EndDate),
+			Metrics: make(map[string]string, len(metrics)),
+		}
+		for _, metric := range metrics {
+			if value := bucket.Metric(metric); value != nil {
+				b.Metrics[string(metric)] = value.String()
+			}
+		}
+		res.Buckets = append(res.Buckets, b)
+	}
+	return res, nil
+}
+
+func (s economicServer) GetDashboard(ctx context.Context, req *pulsepb.GetDashboardRequest) (*pulsepb.GetDashboardResponse, error) {
+	summaries, err := s.app.services.Economicdashservice.GetDashboardSummary()
+	if err != nil {
+		return nil, grpcServerError(ctx, err)
+	}
+
+	res := &pulsepb.GetDashboardResponse{Summaries: make([]*pulsepb.Summary, 0, len(*summaries))}
+	for _, summary := range *summaries {
+		sm := &pulsepb.Summary{
+			Slug:       summary.Slug,
+			Name:       summary.Name,
+			LastUpdate: timestamppb.New(summary.LastUpdate),
+			Value:      summary.Value.String(),
+			Change:     grpcNullDecimal(summary.Change),
+		}
+		if maturity, ok := summary.Extras["maturity"]; ok {
+			m := fmt.Sprint(maturity)
+			sm.Maturity = &m
+		}
+		res.Summaries = append(res.Summaries, sm)
+	}
+	return res, nil
+}
+
+// grpcDateRange reads the date range the same way as readDateRange, from and to when either is set, otherwise the
+// years back from today
+func grpcDateRange(dr *pulsepb.DateRange, v *validator.Validator) data.DateRange {
+	now := time.Now()
+	var dateRange data.DateRange
+	if dr.GetFrom() == nil && dr.GetTo() == nil {
+		years := grpcInt(dr.GetYears(), 10)
+		checkYears(years, data.Unknown, v)
+		dateRange = data.DateRangeFromYears(years, now)
+	} else {
+		dateRange = data.DateRange{To: now}
+		if dr.GetFrom() != nil {
+			dateRange.From = dr.GetFrom().AsTime()
+		}
+		if dr.GetTo() != nil {
+			dateRange.To = dr.GetTo().AsTime()
+		}
+	}
+	if dr.GetAsOf() != nil {
+		dateRange.AsOf = dr.GetAsOf().AsTime()
+	}
+	return dateRange
+}
+
+// grpcInt is the value of an int field, or the default when it is the zero value
+func grpcInt(value int32, defaultValue int) int {
+	if value == 0 {
+		return defaultValue
+	}
+	return int(value)
+}
+
+func grpcTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func grpcNullDecimal(d decimal.NullDecimal) *string {
+	if !d.Valid {
+		return nil
+	}
+	s := d.Decimal.String()
+	return &s
+}
+
+// grpcServerError logs the error and hides it from the client, the same as serverErrorResponse
+func grpcServerError(ctx context.Context, err error) error {
+	utils.Logger(ctx).Error("grpc application error", zap.Error(err))
+	return status.Error(codes.Internal, "the server encountered a problem and could not process your request")
+}
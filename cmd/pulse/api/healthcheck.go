@@ -12,6 +12,8 @@ func (app *application) healthcheckHandler(w http.ResponseWriter, r *http.Reques
 			"environment": app.cfg.Env,
 			"version":     "1.0.0",
 		},
+		"dashboard_cache": app.services.Economicdashservice.CacheStats(),
+		"catalog_cache":   app.services.CatalogService.CacheStats(),
 	}
 
 	err := app.WriteJson(w, http.StatusOK, env, nil)
@@ -13,8 +13,11 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
+const dateLayout = "2006-01-02"
+
 type envelope map[string]interface{}
 
 func (app *application) readInt(qs url.Values, key string, defaultValue int, v *validator.Validator) int {
@@ -31,6 +34,36 @@ func (app *application) readInt(qs url.Values, key string, defaultValue int, v *
 	return i
 }
 
+func (app *application) readCSV(qs url.Values, key string, defaultValue []string) []string {
+	csv := qs.Get(key)
+	if csv == "" {
+		return defaultValue
+	}
+	return strings.Split(csv, ",")
+}
+
+func (app *application) readString(qs url.Values, key string, defaultValue string) string {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+	return s
+}
+
+func (app *application) readDate(qs url.Values, key string, defaultValue time.Time, v *validator.Validator) time.Time {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		v.AddError(key, "must be a date in the format YYYY-MM-DD")
+		return defaultValue
+	}
+	return t
+}
+
 func (app *application) WriteJson(w http.ResponseWriter, status int, data envelope, headers http.Header) error {
 	js, err := json.Marshal(data)
 
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+func (app *application) recessionIndicatorsHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+
+	dateRange := app.readDateRange(r.URL.Query(), data.Unknown, v)
+	data.ValidateDateRange(v, dateRange)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	indicators, err := app.services.IndicatorService.Recession(r.Context(), dateRange)
+	if err != nil {
+		utils.Logger(r.Context()).Error("recessionIndicatorsHandler error getting indicators", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{
+		"data": indicators,
+		"meta": map[string]interface{}{
+			"from": dateRange.From,
+			"to":   dateRange.To,
+		},
+	}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("recessionIndicatorsHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/mhamm84/pulse-api/internal/data"
@@ -19,6 +20,9 @@ import (
 
 const AuthorizationHeader = "Authorization"
 
+// errInvalidAuthenticationToken is returned by authenticateToken for a malformed, unknown or expired token
+var errInvalidAuthenticationToken = errors.New("invalid or missing authentication token")
+
 func (app *application) addRequestId(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
@@ -42,16 +46,12 @@ func (app *application) requirePermissions(code string, next http.HandlerFunc) h
 		utils.Logger(r.Context()).Info("requirePermissions",
 			zap.String("code", code),
 		)
-		permissions, err := app.services.PermissionsService.GetAllForUser(r.Context(), user.ID)
+		permitted, err := app.permitted(r.Context(), user, code)
 		if err != nil {
 			app.serverErrorResponse(w, r, err)
 			return
 		}
-		utils.Logger(r.Context()).Info("requirePermissions",
-			zap.String("user.ID", code),
-			zap.Any("permissions", permissions),
-		)
-		if !permissions.Included(code) {
+		if !permitted {
 			app.notPermittedResponse(w, r)
 			return
 		}
@@ -93,33 +93,10 @@ func (app *application) authenticate(next http.Handler) http.Handler {
 
 		w.Header().Add("Vary", AuthorizationHeader)
 
-		authHeader := r.Header.Get(AuthorizationHeader)
-		if authHeader == "" {
-			app.contextSetUser(r, data.AnonymousUser)
-			next.ServeHTTP(w, r)
-			return
-		}
-
-		splitAuthHeader := strings.Split(authHeader, " ")
-		if len(splitAuthHeader) != 2 || splitAuthHeader[0] != "Bearer" {
-			app.invalidAuthenticationTokenResponse(w, r)
-			return
-		}
-
-		token := splitAuthHeader[1]
-
-		v := validator.New()
-
-		services.ValidateTokenPlaintext(v, token)
-		if !v.Valid() {
-			app.invalidAuthenticationTokenResponse(w, r)
-			return
-		}
-
-		user, err := app.services.UserService.GetFromToken(r.Context(), data.ScopeAuthentication, token)
+		user, err := app.authenticateToken(r.Context(), r.Header.Get(AuthorizationHeader))
 		if err != nil {
 			switch {
-			case errors.Is(err, data.ErrRecordNotFound):
+			case errors.Is(err, errInvalidAuthenticationToken):
 				app.invalidAuthenticationTokenResponse(w, r)
 			default:
 				app.serverErrorResponse(w, r, err)
@@ -132,6 +109,51 @@ func (app *application) authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// authenticateToken gets the user of the bearer token of an authorization header, or the anonymous user when there is
+// no header. It is shared by the authenticate middleware and the gRPC interceptors
+func (app *application) authenticateToken(ctx context.Context, header string) (*data.User, error) {
+	if header == "" {
+		return data.AnonymousUser, nil
+	}
+
+	splitAuthHeader := strings.Split(header, " ")
+	if len(splitAuthHeader) != 2 || splitAuthHeader[0] != "Bearer" {
+		return nil, errInvalidAuthenticationToken
+	}
+
+	token := splitAuthHeader[1]
+
+	v := validator.New()
+
+	services.ValidateTokenPlaintext(v, token)
+	if !v.Valid() {
+		return nil, errInvalidAuthenticationToken
+	}
+
+	user, err := app.services.UserService.GetFromToken(ctx, data.ScopeAuthentication, token)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			return nil, errInvalidAuthenticationToken
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// permitted is whether the user has the permission, it is shared by the requirePermissions middleware and the gRPC
+// interceptors
+func (app *application) permitted(ctx context.Context, user *data.User, code string) (bool, error) {
+	permissions, err := app.services.PermissionsService.GetAllForUser(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	utils.Logger(ctx).Info("requirePermissions",
+		zap.String("user.ID", code),
+		zap.Any("permissions", permissions),
+	)
+	return permissions.Included(code), nil
+}
+
 func (app *application) recoverPanic(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
@@ -145,6 +167,33 @@ func (app *application) recoverPanic(next http.Handler) http.Handler {
 	})
 }
 
+// compress compresses the response with the encoding negotiated from the Accept-Encoding header, a response smaller
+// than the minimum size is written as it is
+func (app *application) compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !app.cfg.Compression.Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: app.cfg.Compression.MinSize}
+		defer func() {
+			err := cw.close()
+			if err != nil {
+				utils.Logger(r.Context()).Info("compress error writing response", zap.Error(err))
+			}
+		}()
+		next.ServeHTTP(cw, r)
+	})
+}
+
 func (app *application) enableCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Vary", "Origin")
@@ -155,6 +204,7 @@ func (app *application) enableCORS(next http.Handler) http.Handler {
 			for i := range app.cfg.Cors.TrustedOrigins {
 				if origin == app.cfg.Cors.TrustedOrigins[i] {
 					w.Header().Set("Access-Control-Allow-Origin", origin)
+					w.Header().Set("Access-Control-Expose-Headers", "X-Current-Page, X-Page-Size, X-First-Page, X-Last-Page, X-Total-Records")
 
 					// preflight request.
 					if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
@@ -0,0 +1,573 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/openapi"
+	"github.com/mhamm84/pulse-api/internal/services/economic"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	contentTypeEventStream = "text/event-stream"
+	bearerAuth             = "bearerAuth"
+)
+
+// routeDoc describes a route in the OpenAPI document, the schemas of its request body and response are generated from
+// the Go values the handler reads and writes
+type routeDoc struct {
+	summary     string
+	description string
+	tag         string
+	params      []*openapi.Parameter
+	body        interface{}
+	// status is the status of a successful response, 200 when it isn't set
+	status int
+	// contentType is the content type of a successful response, application/json when it isn't set
+	contentType string
+	response    interface{}
+	// formats is set when the response can also be negotiated as csv or ndjson
+	formats bool
+	// errors are the error statuses the route responds with, besides the ones every route responds with
+	errors []int
+}
+
+// apiRoutes registers the routes on the router and adds each one to the OpenAPI document as it is registered, so the
+// document can't drift from the router
+type apiRoutes struct {
+	router *httprouter.Router
+	// economic matches the routes under /economic, which are served by a catch-all route of the router per method
+	economic *economicRoutes
+	doc      *openapi.Document
+	permit   func(code string, next http.HandlerFunc) http.HandlerFunc
+	// activated requires an activated user, without a permission
+	activated func(next http.HandlerFunc) http.HandlerFunc
+	// series is the schema of the {slug} path param of the series routes
+	series *openapi.Schema
+}
+
+func newAPIRoutes(router *httprouter.Router, permit func(code string, next http.HandlerFunc) http.HandlerFunc, activated func(next http.HandlerFunc) http.HandlerFunc) *apiRoutes {
+	doc := openapi.New(openapi.Info{
+		Title:       "Pulse API",
+		Description: "Economic data synced from Alpha Vantage",
+		Version:     "1.0.0",
+	})
+	doc.Components.SecuritySchemes[bearerAuth] = &openapi.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "An authentication token created by POST /v1/tokens/authentication",
+	}
+	doc.Components.Schemas["Error"] = &openapi.Schema{
+		Type:        "object",
+		Description: "The error message, or the message of each invalid field when the request fails validation",
+		Properties:  map[string]*openapi.Schema{"error": doc.SchemaOf(openapi.OneOf{"", map[string]string{}})},
+	}
+
+	economic := newEconomicRoutes(WithVersion("/%s/economic/"), router.NotFound, router.MethodNotAllowed)
+	for _, method := range economicMethods {
+		router.Handler(method, economic.prefix+"*path", economic)
+	}
+
+	return &apiRoutes{router: router, economic: economic, doc: doc, permit: permit, activated: activated, series: &openapi.Schema{Type: "string"}}
+}
+
+func (rs *apiRoutes) handle(method, path string, doc routeDoc, handler http.HandlerFunc) {
+	rs.register(method, path, handler)
+	rs.document(method, path, doc, false)
+}
+
+// handlePermitted registers a route which requires the economic permission
+func (rs *apiRoutes) handlePermitted(method, path string, doc routeDoc, handler http.HandlerFunc) {
+	rs.register(method, path, rs.permit(economicPermission, handler))
+	rs.document(method, path, doc, true)
+}
+
+// handleActivated registers a route which requires an activated user, but no permission
+func (rs *apiRoutes) handleActivated(method, path string, doc routeDoc, handler http.HandlerFunc) {
+	rs.register(method, path, rs.activated(handler))
+	rs.document(method, path, doc, true)
+}
+
+// register adds the route to the router, or to the economic routes when it is under /economic
+func (rs *apiRoutes) register(method, path string, handler http.HandlerFunc) {
+	if strings.HasPrefix(path, rs.economic.prefix) {
+		rs.economic.add(method, path, handler)
+		return
+	}
+	rs.router.HandlerFunc(method, path, handler)
+}
+
+// handleSeries registers a /economic/:slug route of the series, which requires the economic permission. The slug only
+// matches the paths of the registry, which the param is documented with, and is resolved by the handler
+func (rs *apiRoutes) handleSeries(suffix string, paths []string, doc routeDoc, handler http.HandlerFunc) {
+	rs.economic.restrict(slugParam, paths)
+	rs.series.Enum = paths
+	series := &openapi.Parameter{Name: slugParam, In: "path", Required: true, Schema: rs.series}
+	doc.params = append([]*openapi.Parameter{series}, doc.params...)
+	rs.handlePermitted(http.MethodGet, WithVersion("/%s/economic/:"+slugParam+suffix), doc, handler)
+}
+
+// document adds the route to the OpenAPI document
+func (rs *apiRoutes) document(method, path string, doc routeDoc, permitted bool) {
+	op := &openapi.Operation{
+		Summary:     doc.summary,
+		Description: doc.description,
+		Parameters:  doc.params,
+		Responses:   make(map[string]*openapi.Response),
+	}
+	if doc.tag != "" {
+		op.Tags = []string{doc.tag}
+	}
+
+	// Path params are taken from the httprouter path, unless the route describes them
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		name := strings.TrimPrefix(segment, ":")
+		segments[i] = "{" + name + "}"
+		if !hasParam(op.Parameters, name) {
+			op.Parameters = append(op.Parameters, &openapi.Parameter{Name: name, In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}})
+		}
+	}
+
+	if doc.body != nil {
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]*openapi.MediaType{contentTypeJson: {Schema: rs.doc.SchemaOf(doc.body)}},
+		}
+	}
+
+	status, contentType := doc.status, doc.contentType
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if contentType == "" {
+		contentType = contentTypeJson
+	}
+	success := &openapi.Response{Description: http.StatusText(status)}
+	if doc.response != nil {
+		success.Content = map[string]*openapi.MediaType{contentType: {Schema: rs.doc.SchemaOf(doc.response)}}
+		if doc.formats {
+			success.Content[contentTypeCsv] = &openapi.MediaType{Schema: &openapi.Schema{Type: "string"}}
+			success.Content[contentTypeNdjson] = &openapi.MediaType{Schema: &openapi.Schema{Type: "string"}}
+		}
+	}
+	op.Responses[fmt.Sprint(status)] = success
+
+	errors := append([]int{http.StatusInternalServerError}, doc.errors...)
+	if len(doc.params) > 0 || doc.body != nil {
+		errors = append(errors, http.StatusUnprocessableEntity)
+	}
+	if permitted {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+		errors = append(errors, http.StatusUnauthorized, http.StatusForbidden)
+	}
+	for _, status := range errors {
+		op.Responses[fmt.Sprint(status)] = &openapi.Response{
+			Description: http.StatusText(status),
+			Content:     map[string]*openapi.MediaType{contentTypeJson: {Schema: &openapi.Schema{Ref: "#/components/schemas/Error"}}},
+		}
+	}
+
+	rs.doc.Add(method, strings.Join(segments, "/"), op)
+}
+
+func hasParam(params []*openapi.Parameter, name string) bool {
+	for _, p := range params {
+		if p.Name == name && p.In == "path" {
+			return true
+		}
+	}
+	return false
+}
+
+// openAPIHandler serves the OpenAPI document of the routes
+func (app *application) openAPIHandler(doc *openapi.Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		js, err := json.Marshal(doc)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeJson)
+		w.Write(append(js, '\n'))
+	}
+}
+
+func queryParam(name, description string, schema *openapi.Schema) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// csvParam is a query param holding a comma separated list
+func csvParam(name, description string, items *openapi.Schema, def []string) *openapi.Parameter {
+	explode := false
+	s := &openapi.Schema{Type: "array", Items: items}
+	if len(def) > 0 {
+		s.Default = def
+	}
+	return &openapi.Parameter{Name: name, In: "query", Description: description, Style: "form", Explode: &explode, Schema: s}
+}
+
+func intSchema(def, min, max int) *openapi.Schema {
+	s := &openapi.Schema{Type: "integer", Format: "int32", Default: def, Minimum: &min}
+	if max > 0 {
+		s.Maximum = &max
+	}
+	return s
+}
+
+func enumSchema(def string, values ...string) *openapi.Schema {
+	s := &openapi.Schema{Type: "string", Enum: values}
+	if def != "" {
+		s.Default = def
+	}
+	return s
+}
+
+func dateSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Format: "date"}
+}
+
+func dateRangeParams() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		queryParam(yearsParam, "Years of data counting back from today, used when neither from nor to is set", intSchema(10, 1, 0)),
+		queryParam(fromParam, "First date of the data", dateSchema()),
+		queryParam(toParam, "Last date of the data, today by default", dateSchema()),
+		queryParam(asOfParam, "Date of the vintage of the data, the latest values by default. Observations synced before "+
+			"vintages were recorded are approximated as published a lag after their period, of a day for daily series up "+
+			"to 18 months for annual ones", dateSchema()),
+	}
+}
+
+func seriesFilterParams() []*openapi.Parameter {
+	return append(dateRangeParams(),
+		queryParam(frequencyParam, "Frequency to resample the data to, the series' own frequency by default",
+			enumSchema("", string(data.FrequencyWeekly), string(data.FrequencyMonthly), string(data.FrequencyQuarterly), string(data.FrequencyAnnual))),
+		queryParam(aggParam, "Aggregation of the observations resampled to a period",
+			enumSchema(string(data.AggregationLast), string(data.AggregationLast), string(data.AggregationFirst), string(data.AggregationMean), string(data.AggregationMin), string(data.AggregationMax))),
+		queryParam(changeParam, "Change calculated for each observation",
+			enumSchema(string(data.DefaultChange.Type), string(data.ChangePeriodOverPeriod), string(data.ChangeYearOverYear), string(data.ChangeAnnualized))),
+		queryParam(lagParam, "Observations back the pop and annualized changes are calculated against", intSchema(data.DefaultChange.Lag, 1, 1000)),
+	)
+}
+
+func pagingParams() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		queryParam(pageParam, "", intSchema(1, 1, 10_000_000)),
+		queryParam(pageSizeParam, "", intSchema(12, 1, 100)),
+	}
+}
+
+func formatParams() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		queryParam(formatParam, "Format of the response, takes precedence over the Accept header",
+			enumSchema(string(formatJson), string(formatJson), string(formatCsv), string(formatNdjson))),
+	}
+}
+
+func seriesListParam(description string) *openapi.Parameter {
+	return csvParam(seriesParam, description, &openapi.Schema{Type: "string"}, nil)
+}
+
+func joinParams(params ...[]*openapi.Parameter) []*openapi.Parameter {
+	var res []*openapi.Parameter
+	for _, p := range params {
+		res = append(res, p...)
+	}
+	return res
+}
+
+var (
+	healthcheckDoc = routeDoc{
+		summary:  "Health of the API",
+		tag:      "system",
+		response: envelope{"status": "", "system_info": map[string]string{}, "dashboard_cache": economic.CacheStats{}, "catalog_cache": economic.CoverageCacheStats{}},
+	}
+	openAPIDoc = routeDoc{
+		summary:  "OpenAPI document of the API",
+		tag:      "system",
+		response: map[string]interface{}{},
+	}
+	dashboardDoc = routeDoc{
+		summary:     "Latest value and change of every report",
+		description: fmt.Sprintf("The summary is cached until the data sync updates a series, clients may reuse it for %d seconds", dashboardMaxAge),
+		tag:         "economic",
+		response:    envelope{"economicSummaries": []data.Summary{}},
+	}
+	reportsDoc = routeDoc{
+		summary:  "Catalog of the reports",
+		tag:      "economic",
+		response: envelope{"data": []data.CatalogEntry{}},
+	}
+	reportDoc = routeDoc{
+		summary:  "Catalog entry of a report",
+		tag:      "economic",
+		response: envelope{"data": data.CatalogEntry{}},
+		errors:   []int{http.StatusNotFound},
+	}
+	compareDoc = routeDoc{
+		summary: "Series aligned by date",
+		tag:     "economic",
+		params: joinParams(
+			[]*openapi.Parameter{seriesListParam(fmt.Sprintf("Between 2 and %d series to compare", maxSeriesCount))},
+			seriesFilterParams(),
+			[]*openapi.Parameter{queryParam(alignParam, "How dates missing from some series are aligned",
+				enumSchema(string(data.AlignInner), string(data.AlignInner), string(data.AlignOuter), string(data.AlignForwardFill)))},
+		),
+		response: envelope{"data": []data.ComparisonRow{}, "meta": map[string]interface{}{}},
+	}
+	correlationDoc = routeDoc{
+		summary: "Correlation matrix of series",
+		tag:     "economic",
+		params: joinParams(
+			[]*openapi.Parameter{seriesListParam(fmt.Sprintf("Between 2 and %d series to correlate", maxSeriesCount))},
+			seriesFilterParams(),
+			[]*openapi.Parameter{queryParam(methodParam, "", enumSchema(string(data.CorrelationPearson), string(data.CorrelationPearson), string(data.CorrelationSpearman)))},
+		),
+		response: envelope{"data": data.CorrelationMatrix{}, "meta": map[string]time.Time{}},
+	}
+	anomaliesDoc = routeDoc{
+		summary: "Outliers of every report",
+		tag:     "economic",
+		params: joinParams(
+			[]*openapi.Parameter{queryParam(sinceParam, "First date of the outliers, a month ago by default", dateSchema())},
+			pagingParams(),
+		),
+		response: envelope{"data": []data.Anomaly{}, "meta": data.Metadata{}},
+	}
+	streamDoc = routeDoc{
+		summary: "Server-sent events of the observations inserted or revised by the data sync",
+		description: "Each event is named after its kind, its id is the id of the economic event and its data is the " +
+			"event as json. A client reconnecting with the Last-Event-ID header is sent the events it missed first",
+		tag: "economic",
+		params: []*openapi.Parameter{
+			seriesListParam("Series to stream, every series by default"),
+			{Name: "Last-Event-ID", In: "header", Description: "Id of the last event the client got", Schema: &openapi.Schema{Type: "integer", Format: "int64"}},
+			queryParam(lastEventIdParam, "Fallback for clients which can't set the Last-Event-ID header", &openapi.Schema{Type: "integer", Format: "int64"}),
+		},
+		contentType: contentTypeEventStream,
+		response:    data.EconomicEvent{},
+	}
+	recessionDoc = routeDoc{
+		summary:  "Sahm rule and yield curve recession probability",
+		tag:      "economic",
+		params:   dateRangeParams(),
+		response: envelope{"data": data.RecessionIndicators{}, "meta": map[string]time.Time{}},
+	}
+	seriesDoc = routeDoc{
+		summary: "Observations of a series with their change and bucketed stats",
+		description: "The anomaly score of an observation is scored against the current data, so it is left out when " +
+			"the series is resampled or read asOf a past date",
+		tag:      "economic",
+		params:   joinParams(seriesFilterParams(), pagingParams(), formatParams()),
+		response: envelope{"data": []data.EconomicWithChange{}, "meta": data.Metadata{}, "stats": []data.EconomicStats{}},
+		formats:  true,
+	}
+	statsDoc = routeDoc{
+		summary: "Stats of a series bucketed by time",
+		tag:     "economic",
+		params: joinParams(
+			dateRangeParams(),
+			[]*openapi.Parameter{
+				queryParam(timeBucketDaysParam, "Days in each bucket", intSchema(365, 1, 0)),
+				csvParam(metricsParam, "Metrics of each bucket", enumSchema("", data.AllStatsMetrics.Strings()...), data.DefaultStatsMetrics.Strings()),
+			},
+			pagingParams(),
+			formatParams(),
+		),
+		response: envelope{"data": []data.EconomicStats{}, "meta": data.Metadata{}},
+		formats:  true,
+	}
+	rollingDoc = routeDoc{
+		summary:     "Trailing window stats of a series",
+		description: "The mean and stddev are of the window ending at each observation, the zscore is against the window of observations before it",
+		tag:         "economic",
+		params: joinParams(
+			seriesFilterParams(),
+			[]*openapi.Parameter{
+				queryParam(windowParam, "Observations in the window", intSchema(12, 2, 1000)),
+				csvParam(fnParam, "Functions of the window", enumSchema("", string(data.RollingMean), string(data.RollingStddev), string(data.RollingZScore)), []string{string(data.RollingMean)}),
+			},
+			pagingParams(),
+		),
+		response: envelope{"data": []data.RollingStats{}, "meta": data.Metadata{}},
+	}
+	revisionsDoc = routeDoc{
+		summary: "Revisions of a series",
+		description: "The revision history of the observation on the date, or without a date the largest revisions " +
+			"made since the since date",
+		tag: "economic",
+		params: joinParams(
+			[]*openapi.Parameter{
+				queryParam(dateParam, "Date of the observation", dateSchema()),
+				queryParam(sinceParam, "First date of the revisions, a year ago by default", dateSchema()),
+			},
+			pagingParams(),
+		),
+		response: envelope{"data": openapi.OneOf{data.RevisionHistory{}, []data.RevisionSummary{}}, "meta": data.Metadata{}},
+		errors:   []int{http.StatusNotFound},
+	}
+	forecastDoc = routeDoc{
+		summary: "Forecast of a series with prediction intervals",
+		tag:     "economic",
+		params: []*openapi.Parameter{
+			queryParam(modelParam, "", enumSchema(string(data.ForecastETS), string(data.ForecastNaive), string(data.ForecastDrift), string(data.ForecastETS), string(data.ForecastHolt))),
+			queryParam(horizonParam, "Periods to forecast", intSchema(12, 1, 120)),
+		},
+		response: envelope{"data": data.Forecast{}},
+	}
+	treasuryYieldCurveDoc = routeDoc{
+		summary:     "Treasury yield curve on a date, and its shift from the curve on the compare date",
+		description: "Each maturity's yield is its latest observation on or up to 5 days before the date, a maturity without one is left out of the curve",
+		tag:         "treasury",
+		params: []*openapi.Parameter{
+			queryParam(dateParam, "Date of the curve, today by default", dateSchema()),
+			queryParam(compareParam, "Date of the curve to compare against", dateSchema()),
+		},
+		response: envelope{"curve": data.YieldCurve{}, "compare": data.YieldCurve{}, "shift": []data.YieldCurveShift{}},
+		errors:   []int{http.StatusNotFound},
+	}
+	treasuryYieldSpreadDoc = routeDoc{
+		summary: "Spread between two treasury yield maturities and its inversions",
+		tag:     "treasury",
+		params: joinParams(
+			[]*openapi.Parameter{
+				queryParam(longParam, "", enumSchema("10y", treasuryMaturities()...)),
+				queryParam(shortParam, "", enumSchema("2y", treasuryMaturities()...)),
+			},
+			dateRangeParams(),
+		),
+		response: envelope{"data": data.YieldSpreadResult{}, "meta": map[string]time.Time{}},
+	}
+	batchDoc = routeDoc{
+		summary: "Data of several series in one request",
+		description: fmt.Sprintf("Each item is validated and fetched as the series route would, up to %d at once. "+
+			"Each item gets its own result or error, with the status the series route would have responded with", batchWorkers),
+		tag: "economic",
+		body: struct {
+			Items []batchItem `json:"items"`
+		}{},
+		response: envelope{"data": []batchResult{}},
+		errors:   []int{http.StatusBadRequest},
+	}
+	graphqlDoc = routeDoc{
+		summary: "GraphQL query over the reports, series, dashboard and current user",
+		tag:     "graphql",
+		body: struct {
+			Query         string                 `json:"query"`
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+		}{},
+		response: envelope{"data": map[string]interface{}{}, "errors": []map[string]interface{}{}},
+		errors:   []int{http.StatusBadRequest},
+	}
+	listDashboardsDoc = routeDoc{
+		summary:     "Dashboards of the user",
+		description: "The default dashboard, with an id of 0, is the only dashboard of a user who hasn't created one",
+		tag:         "dashboards",
+		response:    envelope{"dashboards": []data.Dashboard{}},
+	}
+	createDashboardDoc = routeDoc{
+		summary: "Create a dashboard of the series, in the order of the items",
+		description: fmt.Sprintf("Up to %d items, the change of an item defaults to pop and its name to the display name "+
+			"of its report", data.MaxDashboardItems),
+		tag:      "dashboards",
+		body:     dashboardInput{},
+		status:   http.StatusCreated,
+		response: envelope{"dashboard": data.Dashboard{}},
+		errors:   []int{http.StatusBadRequest},
+	}
+	showDashboardDoc = routeDoc{
+		summary:  "Dashboard of the user with the latest value and change of each of its series",
+		params:   []*openapi.Parameter{dashboardIDParam()},
+		tag:      "dashboards",
+		response: envelope{"dashboard": data.Dashboard{}, "economicSummaries": []data.Summary{}},
+		errors:   []int{http.StatusNotFound},
+	}
+	updateDashboardDoc = routeDoc{
+		summary:     "Replace the name and items of a dashboard",
+		description: "The default dashboard can't be updated. The update is rejected when the version is given and the dashboard has been edited since",
+		params:      []*openapi.Parameter{dashboardIDParam()},
+		tag:         "dashboards",
+		body:        dashboardInput{},
+		response:    envelope{"dashboard": data.Dashboard{}},
+		errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	}
+	deleteDashboardDoc = routeDoc{
+		summary:  "Delete a dashboard",
+		params:   []*openapi.Parameter{dashboardIDParam()},
+		tag:      "dashboards",
+		response: envelope{"message": ""},
+		errors:   []int{http.StatusNotFound},
+	}
+	registerUserDoc = routeDoc{
+		summary: "Register a user, the activation token is emailed to them",
+		tag:     "users",
+		body: struct {
+			Name     string `json:"name"`
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}{},
+		status:   http.StatusCreated,
+		response: envelope{"user": data.User{}},
+		errors:   []int{http.StatusBadRequest},
+	}
+	activateUserDoc = routeDoc{
+		summary: "Activate a user with their activation token",
+		tag:     "users",
+		body: struct {
+			Token string `json:"token"`
+		}{},
+		response: envelope{"user": data.User{}},
+		errors:   []int{http.StatusBadRequest, http.StatusConflict},
+	}
+	activationTokenDoc = routeDoc{
+		summary: "Email a new activation token to a user",
+		tag:     "tokens",
+		body: struct {
+			Email string `json:"email"`
+		}{},
+		errors: []int{http.StatusBadRequest},
+	}
+	authenticationTokenDoc = routeDoc{
+		summary: "Create an authentication token",
+		tag:     "tokens",
+		body: struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}{},
+		status:   http.StatusCreated,
+		response: envelope{"authentication_token": data.Token{}},
+		errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+	}
+)
+
+// treasuryMaturityParam is the :maturity path param of the treasury yield routes
+func treasuryMaturityParam() *openapi.Parameter {
+	return &openapi.Parameter{Name: "maturity", In: "path", Required: true, Schema: enumSchema("", treasuryMaturities()...)}
+}
+
+func dashboardIDParam() *openapi.Parameter {
+	return &openapi.Parameter{Name: "id", In: "path", Required: true, Description: "0 is the default dashboard",
+		Schema: &openapi.Schema{Type: "integer", Format: "int64"}}
+}
+
+func treasuryMaturities() []string {
+	res := make([]string, len(data.TreasuryMaturities))
+	for i, m := range data.TreasuryMaturities {
+		res[i] = string(m)
+	}
+	return res
+}
+
+// withParams copies the route with the params added before its own
+func (d routeDoc) withParams(params ...*openapi.Parameter) routeDoc {
+	d.params = append(params, d.params...)
+	return d
+}
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sourceRoute is a route registered in routes.go, with the params its handler reads
+type sourceRoute struct {
+	method string
+	path   string
+	params map[string]bool
+}
+
+// packageSource holds the declarations of the package the routes and the params their handlers read are found from
+type packageSource struct {
+	consts map[string]string
+	// params are the values of the consts naming a param
+	params map[string]string
+	// funcs are the functions by name, and the methods by the name of their type and their name
+	funcs map[string][]*ast.FuncDecl
+	reads map[string]map[string]bool
+}
+
+func parsePackageSource(t *testing.T) *packageSource {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	src := &packageSource{
+		consts: make(map[string]string),
+		params: make(map[string]string),
+		funcs:  make(map[string][]*ast.FuncDecl),
+		reads:  make(map[string]map[string]bool),
+	}
+	for _, file := range pkgs["api"].Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name := decl.Name.Name
+				if decl.Recv != nil {
+					name = typeName(decl.Recv.List[0].Type) + "." + name
+				}
+				src.funcs[name] = append(src.funcs[name], decl)
+			case *ast.GenDecl:
+				if decl.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.ValueSpec)
+					for i, name := range spec.Names {
+						if i >= len(spec.Values) {
+							continue
+						}
+						if lit, ok := spec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							value, err := strconv.Unquote(lit.Value)
+							require.NoError(t, err)
+							src.consts[name.Name] = value
+							if strings.HasSuffix(name.Name, "Param") {
+								src.params[name.Name] = value
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return src
+}
+
+// eval evaluates a string expression of literals and consts
+func (src *packageSource) eval(t *testing.T, expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		value, err := strconv.Unquote(expr.Value)
+		require.NoError(t, err)
+		return value
+	case *ast.Ident:
+		value, ok := src.consts[expr.Name]
+		require.True(t, ok, "%s is not a string const", expr.Name)
+		return value
+	case *ast.BinaryExpr:
+		return src.eval(t, expr.X) + src.eval(t, expr.Y)
+	}
+	t.Fatalf("unsupported path expression %T", expr)
+	return ""
+}
+
+// paramsRead are the params read by the functions the node refers to, and by the functions they call in turn
+func (src *packageSource) paramsRead(node ast.Node) map[string]bool {
+	params := make(map[string]bool)
+	refer := func(name string) {
+		if param, ok := src.params[name]; ok {
+			params[param] = true
+		}
+		if _, ok := src.funcs[name]; ok {
+			for param := range src.funcParamsRead(name) {
+				params[param] = true
+			}
+		}
+	}
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			// a method is only followed when the type of the receiver or param it's selected on is declared
+			x, ok := n.X.(*ast.Ident)
+			if !ok {
+				ast.Inspect(n.X, inspect)
+				return false
+			}
+			if x.Obj != nil {
+				if field, ok := x.Obj.Decl.(*ast.Field); ok {
+					refer(typeName(field.Type) + "." + n.Sel.Name)
+				}
+			}
+			return false
+		case *ast.Ident:
+			if n.Obj == nil || n.Obj.Kind != ast.Var {
+				refer(n.Name)
+			}
+		}
+		return true
+	}
+	ast.Inspect(node, inspect)
+	return params
+}
+
+func (src *packageSource) funcParamsRead(name string) map[string]bool {
+	if params, ok := src.reads[name]; ok {
+		return params
+	}
+	// a recursive call reads no more params than the function already does
+	src.reads[name] = map[string]bool{}
+	params := make(map[string]bool)
+	for _, decl := range src.funcs[name] {
+		if decl.Body == nil {
+			continue
+		}
+		for param := range src.paramsRead(decl.Body) {
+			params[param] = true
+		}
+	}
+	src.reads[name] = params
+	return params
+}
+
+// routes finds the routes registered in routes.go
+func (src *packageSource) routes(t *testing.T) []sourceRoute {
+	var routes []sourceRoute
+	for _, decl := range src.funcs["application.routes"] {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			// routes added to the router directly aren't documented, so they are found too
+			switch x.Name + "." + sel.Sel.Name {
+			case "routes.handle", "routes.handlePermitted", "routes.handleActivated", "router.Handle", "router.Handler", "router.HandlerFunc":
+				method := call.Args[0].(*ast.SelectorExpr).Sel.Name
+				path := call.Args[1].(*ast.CallExpr)
+				routes = append(routes, sourceRoute{
+					method: strings.ToUpper(strings.TrimPrefix(method, "Method")),
+					path:   WithVersion(src.eval(t, path.Args[0])),
+					params: src.paramsRead(call.Args[len(call.Args)-1]),
+				})
+			case "routes.handleSeries":
+				routes = append(routes, sourceRoute{
+					method: http.MethodGet,
+					path:   WithVersion("/%s/economic/:" + slugParam + src.eval(t, call.Args[0])),
+					params: src.paramsRead(call.Args[3]),
+				})
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, routes)
+	return routes
+}
+
+// typeName is the name of the type of a receiver or param, without the pointer
+func typeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// documentPath is the path of the OpenAPI document of the httprouter path
+func documentPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// TestOpenAPI checks the OpenAPI document served by the routes describes every route registered in routes.go, and
+// every param its handler reads, as the params of the routes are described by hand
+func TestOpenAPI(t *testing.T) {
+	app := application{registry: data.NewSeriesRegistry(nil)}
+	rr := httptest.NewRecorder()
+	app.routes().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, WithVersion("/%s/openapi.json"), nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+
+	src := parsePackageSource(t)
+	routes := src.routes(t)
+	documented := 0
+	for _, ops := range doc.Paths {
+		documented += len(ops)
+	}
+	assert.Equal(t, len(routes), documented, "routes registered and documented")
+
+	for _, route := range routes {
+		route := route
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			op := doc.Operation(route.method, documentPath(route.path))
+			require.NotNil(t, op, "route is not documented")
+
+			var documentedParams []string
+			for _, p := range op.Parameters {
+				if p.In == "path" || p.In == "query" {
+					documentedParams = append(documentedParams, p.In+" "+p.Name)
+				}
+			}
+			var readParams []string
+			pathParams := make(map[string]bool)
+			for _, segment := range strings.Split(route.path, "/") {
+				if strings.HasPrefix(segment, ":") {
+					pathParams[strings.TrimPrefix(segment, ":")] = true
+					readParams = append(readParams, "path "+strings.TrimPrefix(segment, ":"))
+				}
+			}
+			// the params of a route with a request body are the fields of the body, which are described by its schema
+			if op.RequestBody == nil {
+				for param := range route.params {
+					if !pathParams[param] {
+						readParams = append(readParams, "query "+param)
+					}
+				}
+			}
+			sort.Strings(documentedParams)
+			sort.Strings(readParams)
+
+			assert.Equal(t, readParams, documentedParams)
+		})
+	}
+}
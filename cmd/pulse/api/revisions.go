@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const sinceParam = "since"
+
+// revisionsHandler serves the revision history of the observation on the date param, or without a date a summary
+// of the largest revisions made since the since param, a year ago by default
+func (app *application) revisionsHandler(s data.Series) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := validator.New()
+		qs := r.URL.Query()
+
+		date := app.readDate(qs, dateParam, time.Time{}, v)
+		if !v.Valid() {
+			app.failedValidationResponse(w, r, v.Errors)
+			return
+		}
+		if !date.IsZero() {
+			app.revisionHistory(s, date, w, r)
+			return
+		}
+
+		since := app.readDate(qs, sinceParam, time.Now().AddDate(-1, 0, 0), v)
+		paging := data.Paging{
+			Page:     app.readInt(qs, pageParam, 1, v),
+			PageSize: app.readInt(qs, pageSizeParam, 12, v),
+		}
+		data.ValidatePaging(v, paging)
+		if !v.Valid() {
+			app.failedValidationResponse(w, r, v.Errors)
+			return
+		}
+
+		res, err := app.services.RevisionService.Summary(r.Context(), s.ReportType, since, paging)
+		if err != nil {
+			utils.Logger(r.Context()).Error("revisionsHandler error getting revisions", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+
+		err = app.WriteJson(w, http.StatusOK, envelope{
+			"data": res.Data,
+			"meta": res.Meta,
+		}, nil)
+		if err != nil {
+			utils.Logger(r.Context()).Error("revisionsHandler error writing json", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+		}
+	}
+}
+
+func (app *application) revisionHistory(s data.Series, date time.Time, w http.ResponseWriter, r *http.Request) {
+	history, err := app.services.RevisionService.History(r.Context(), s.ReportType, date)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundHandler(w, r)
+		default:
+			utils.Logger(r.Context()).Error("revisionHistory error getting revision history", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{"data": history}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("revisionHistory error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -16,53 +16,58 @@ func (app application) routes() http.Handler {
 	router.NotFound = http.HandlerFunc(app.notFoundHandler)
 	router.MethodNotAllowed = http.HandlerFunc(app.methodNotAllowedResponse)
 
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/healthcheck"), app.healthcheckHandler)
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/dashboard"), app.economicDashHandler)
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/cpi"), app.requirePermissions(economicPermission, app.cpiDataByYears))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/cpi/stats"), app.requirePermissions(economicPermission, app.cpiStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/inflation_expectation"), app.requirePermissions(economicPermission, app.inflationExpectation))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/inflation_expectation/stats"), app.requirePermissions(economicPermission, app.inflationExpectationStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/inflation"), app.requirePermissions(economicPermission, app.inflation))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/inflation/stats"), app.requirePermissions(economicPermission, app.inflationStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/nonfarm_payroll"), app.requirePermissions(economicPermission, app.nonfarmPayroll))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/nonfarm_payroll/stats"), app.requirePermissions(economicPermission, app.nonfarmPayrollStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/unemployment"), app.requirePermissions(economicPermission, app.unemployemnt))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/unemployment/stats"), app.requirePermissions(economicPermission, app.unemployemntStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/durable_goods_orders"), app.requirePermissions(economicPermission, app.durableGoodsOrders))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/durable_goods_orders/stats"), app.requirePermissions(economicPermission, app.durableGoodsOrdersStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/federal_funds_rate"), app.requirePermissions(economicPermission, app.federalFundsRate))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/federal_funds_rate/stats"), app.requirePermissions(economicPermission, app.federalFundsRateStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/real_gdp"), app.requirePermissions(economicPermission, app.realGdpDataByYears))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/real_gdp/stats"), app.requirePermissions(economicPermission, app.realGdpDataByYearsStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/real_gdp_per_capita"), app.requirePermissions(economicPermission, app.realGdpPerCapitaDataByYears))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/real_gdp_per_capita/stats"), app.requirePermissions(economicPermission, app.realGdpPerCapitaStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/consumer_sentiment"), app.requirePermissions(economicPermission, app.consumerSentimentDataByYears))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/consumer_sentiment/stats"), app.requirePermissions(economicPermission, app.consumerSentimentStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/retail_sales"), app.requirePermissions(economicPermission, app.retailSalesDataByYears))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/retail_sales/stats"), app.requirePermissions(economicPermission, app.retailSalesDataByYearsStats))
-
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/treasury_yield/:maturity"), app.requirePermissions(economicPermission, app.treasuryYieldByYears))
-	router.HandlerFunc(http.MethodGet, WithVersion("/%s/economic/treasury_yield/:maturity/stats"), app.requirePermissions(economicPermission, app.treasuryYieldByYearsStats))
-
-	router.HandlerFunc(http.MethodPost, WithVersion("/%s/users"), app.registerUserHandler)
-	router.HandlerFunc(http.MethodPut, WithVersion("/%s/users/activated"), app.activateUserHandler)
-
-	router.HandlerFunc(http.MethodPost, WithVersion("/%s/tokens/activation"), app.createActivationTokenHandler)
-	router.HandlerFunc(http.MethodPost, WithVersion("/%s/tokens/authentication"), app.createAuthenticationTokenHandler)
-
-	return app.recoverPanic(app.enableCORS(app.addRequestId(app.rateLimit(app.authenticate(router)))))
+	// Every route is added to the OpenAPI document as it is registered
+	routes := newAPIRoutes(router, app.requirePermissions, app.requireActivatedUser)
+
+	routes.handle(http.MethodGet, WithVersion("/%s/healthcheck"), healthcheckDoc, app.healthcheckHandler)
+
+	routes.handle(http.MethodGet, WithVersion("/%s/economic/dashboard"), dashboardDoc, app.economicDashHandler)
+	routes.handle(http.MethodGet, WithVersion("/%s/economic/reports"), reportsDoc, app.reportsHandler)
+	routes.handle(http.MethodGet, WithVersion("/%s/economic/reports/:slug"), reportDoc, app.reportHandler)
+
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/compare"), compareDoc, app.compareHandler)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/correlation"), correlationDoc, app.correlationHandler)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/anomalies"), anomaliesDoc, app.anomaliesHandler)
+	routes.handlePermitted(http.MethodGet, WithVersion(streamPath), streamDoc, app.streamHandler)
+	routes.handlePermitted(http.MethodPost, WithVersion("/%s/economic/batch"), batchDoc, app.batchHandler)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/indicators/recession"), recessionDoc, app.recessionIndicatorsHandler)
+
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/treasury_yield/"+treasuryYieldCurve), treasuryYieldCurveDoc, app.treasuryYieldCurveHandler)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/treasury_yield/"+treasuryYieldSpread), treasuryYieldSpreadDoc, app.treasuryYieldSpreadHandler)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/treasury_yield/:maturity"), seriesDoc.withParams(treasuryMaturityParam()), app.treasuryYieldByYears)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/treasury_yield/:maturity/stats"), statsDoc.withParams(treasuryMaturityParam()), app.treasuryYieldByYearsStats)
+	routes.handlePermitted(http.MethodGet, WithVersion("/%s/economic/treasury_yield/:maturity/rolling"), rollingDoc.withParams(treasuryMaturityParam()), app.treasuryYieldRolling)
+
+	// Every series in the registry is served by the /economic/:slug routes, the static economic routes take precedence
+	// over the slug. Unknown slugs are not found
+	paths := app.registry.Paths()
+	routes.handleSeries("", paths, seriesDoc, app.seriesRoute(app.seriesHandler))
+	routes.handleSeries("/stats", paths, statsDoc, app.seriesRoute(app.seriesStatsHandler))
+	routes.handleSeries("/rolling", paths, rollingDoc, app.seriesRoute(app.rollingHandler))
+	routes.handleSeries("/revisions", paths, revisionsDoc, app.seriesRoute(app.revisionsHandler))
+	routes.handleSeries("/forecast", paths, forecastDoc, app.seriesRoute(app.forecastHandler))
+
+	schema, err := app.newGraphQLSchema()
+	if err != nil {
+		panic(fmt.Sprintf("invalid graphql schema: %s", err))
+	}
+	routes.handlePermitted(http.MethodPost, WithVersion("/%s/graphql"), graphqlDoc, app.graphqlHandler(schema))
+
+	routes.handleActivated(http.MethodGet, WithVersion("/%s/dashboards"), listDashboardsDoc, app.listDashboardsHandler)
+	routes.handleActivated(http.MethodPost, WithVersion("/%s/dashboards"), createDashboardDoc, app.createDashboardHandler)
+	routes.handleActivated(http.MethodGet, WithVersion("/%s/dashboards/:id"), showDashboardDoc, app.showDashboardHandler)
+	routes.handleActivated(http.MethodPut, WithVersion("/%s/dashboards/:id"), updateDashboardDoc, app.updateDashboardHandler)
+	routes.handleActivated(http.MethodDelete, WithVersion("/%s/dashboards/:id"), deleteDashboardDoc, app.deleteDashboardHandler)
+
+	routes.handle(http.MethodPost, WithVersion("/%s/users"), registerUserDoc, app.registerUserHandler)
+	routes.handle(http.MethodPut, WithVersion("/%s/users/activated"), activateUserDoc, app.activateUserHandler)
+
+	routes.handle(http.MethodPost, WithVersion("/%s/tokens/activation"), activationTokenDoc, app.createActivationTokenHandler)
+	routes.handle(http.MethodPost, WithVersion("/%s/tokens/authentication"), authenticationTokenDoc, app.createAuthenticationTokenHandler)
+
+	routes.handle(http.MethodGet, WithVersion("/%s/openapi.json"), openAPIDoc, app.openAPIHandler(routes.doc))
+
+	return app.recoverPanic(app.compress(app.enableCORS(app.addRequestId(app.rateLimit(app.authenticate(router))))))
 }
 
 func WithVersion(pathFmt string) string {
@@ -14,14 +14,28 @@ import (
 )
 
 func (app *application) serve() error {
+	routes := app.routes()
+	timeout := http.TimeoutHandler(routes, 5*time.Second, "timeout limit of request reached")
 	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", app.cfg.Port),
-		Handler:      http.TimeoutHandler(app.routes(), 5*time.Second, "timeout limit of request reached"),
+		Addr: fmt.Sprintf(":%d", app.cfg.Port),
+		// The stream outlives the timeout and has to flush its events, which the timeout handler's writer can't do
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == WithVersion(streamPath) {
+				routes.ServeHTTP(w, r)
+				return
+			}
+			timeout.ServeHTTP(w, r)
+		}),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
 
+	grpcSrv, err := app.serveGRPC()
+	if err != nil {
+		return err
+	}
+
 	shutdownError := make(chan error)
 
 	go func() {
@@ -41,6 +55,7 @@ func (app *application) serve() error {
 		if err != nil {
 			shutdownError <- err
 		}
+		grpcSrv.GracefulStop()
 		utils.Logger(ctx).Info("completing background tasks...",
 			zap.String("addr", srv.Addr),
 		)
@@ -48,7 +63,7 @@ func (app *application) serve() error {
 		shutdownError <- nil
 	}()
 
-	err := srv.ListenAndServe()
+	err = srv.ListenAndServe()
 	if !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/services/economic"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	streamPath = "/%s/economic/stream"
+	// lastEventIdParam is the fallback for clients which can't set the Last-Event-ID header on reconnect
+	lastEventIdParam = "lastEventId"
+	// streamMaxDuration ends a stream before the server's write timeout, the client reconnects after streamRetry with the
+	// id of the last event it got and the stream picks up from there
+	streamMaxDuration = 25 * time.Second
+	streamRetry       = time.Second
+	// streamKeepAlive is how often a comment is sent when there are no events, so proxies don't close an idle stream
+	streamKeepAlive = 10 * time.Second
+)
+
+// streamHandler pushes the events of the series to the client as server-sent events, as the data sync inserts or revises
+// their observations. A client reconnecting with a Last-Event-ID is first sent the events it missed
+func (app *application) streamHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+
+	qs := r.URL.Query()
+	slugs := app.readStreamSeries(app.readCSV(qs, seriesParam, []string{}), v)
+	lastEventId := app.readLastEventId(r, v)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		app.serverErrorResponse(w, r, errors.New("streaming is not supported by the response writer"))
+		return
+	}
+
+	// Subscribe before replaying so no event is missed between the two, the events which are in both are skipped by id
+	sub := app.services.StreamService.Subscribe(slugs)
+	defer app.services.StreamService.Unsubscribe(sub)
+
+	var replay []data.EconomicEvent
+	if lastEventId != nil {
+		events, err := app.services.StreamService.Replay(r.Context(), *lastEventId, slugs)
+		if err != nil {
+			utils.Logger(r.Context()).Error("streamHandler error replaying events", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+		replay = *events
+	} else {
+		latest, err := app.services.StreamService.LatestID(r.Context())
+		if err != nil {
+			utils.Logger(r.Context()).Error("streamHandler error getting latest event id", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+			return
+		}
+		lastEventId = &latest
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// An id without data sets the id the client reconnects with, without dispatching an event
+	fmt.Fprintf(w, "retry: %d\nid: %d\n\n", streamRetry.Milliseconds(), *lastEventId)
+	lastId := *lastEventId
+	for _, event := range replay {
+		err := writeEvent(w, event)
+		if err != nil {
+			utils.Logger(r.Context()).Info("streamHandler error writing event", zap.Error(err))
+			return
+		}
+		lastId = event.ID
+	}
+	flusher.Flush()
+	// The client missed more events than are replayed at once, it gets the rest when it reconnects
+	if len(replay) == economic.MaxReplayEvents {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	end := time.NewTimer(streamMaxDuration)
+	defer end.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-end.C:
+			return
+		case <-keepAlive.C:
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			// The broker dropped the subscription as the client fell behind, it catches up when it reconnects
+			if !ok {
+				return
+			}
+			if event.ID <= lastId {
+				continue
+			}
+			err := writeEvent(w, event)
+			if err != nil {
+				utils.Logger(r.Context()).Info("streamHandler error writing event", zap.Error(err))
+				return
+			}
+			lastId = event.ID
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event data.EconomicEvent) error {
+	js, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, js)
+	return err
+}
+
+// readStreamSeries resolves the slugs through the registry to the series slugs, every series is streamed when there
+// are none
+func (app *application) readStreamSeries(slugs []string, v *validator.Validator) []string {
+	if len(slugs) == 0 {
+		for _, s := range app.registry.All() {
+			slugs = append(slugs, s.Slug)
+		}
+		return slugs
+	}
+
+	reports := app.readReportTypes(slugs, v)
+	v.Check(validator.Unique(slugs), seriesParam, "must not contain duplicate series")
+	res := make([]string, 0, len(reports))
+	for _, report := range reports {
+		res = append(res, report.ToTable())
+	}
+	return res
+}
+
+// readLastEventId reads the id of the last event the client got, nil when it is connecting for the first time
+func (app *application) readLastEventId(r *http.Request, v *validator.Validator) *int64 {
+	s := r.Header.Get("Last-Event-ID")
+	if s == "" {
+		s = r.URL.Query().Get(lastEventIdParam)
+	}
+	if s == "" {
+		return nil
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id < 0 {
+		v.AddError(lastEventIdParam, "must be a non-negative integer")
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const (
+	treasuryYieldCurve  = "curve"
+	treasuryYieldSpread = "spread"
+
+	dateParam    = "date"
+	compareParam = "compare"
+	longParam    = "long"
+	shortParam   = "short"
+)
+
+func (app *application) treasuryYieldCurveHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Date    time.Time
+		Compare time.Time
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Date = app.readDate(qs, dateParam, time.Now(), v)
+	input.Compare = app.readDate(qs, compareParam, time.Time{}, v)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	curve, err := app.services.TreasuryService.YieldCurve(r.Context(), input.Date)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundHandler(w, r)
+		default:
+			utils.Logger(r.Context()).Error("treasuryYieldCurveHandler error getting curve", zap.Error(err))
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	env := envelope{"curve": curve}
+
+	if !input.Compare.IsZero() {
+		compare, err := app.services.TreasuryService.YieldCurve(r.Context(), input.Compare)
+		if err != nil {
+			switch {
+			case errors.Is(err, data.ErrRecordNotFound):
+				app.notFoundHandler(w, r)
+			default:
+				utils.Logger(r.Context()).Error("treasuryYieldCurveHandler error getting compare curve", zap.Error(err))
+				app.serverErrorResponse(w, r, err)
+			}
+			return
+		}
+		env["compare"] = compare
+		env["shift"] = app.services.TreasuryService.YieldCurveShift(curve, compare)
+	}
+
+	err = app.WriteJson(w, http.StatusOK, env, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("treasuryYieldCurveHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) treasuryYieldSpreadHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Long      data.TreasuryMaturity
+		Short     data.TreasuryMaturity
+		DateRange data.DateRange
+	}
+
+	v := validator.New()
+
+	qs := r.URL.Query()
+	input.Long = data.TreasuryMaturity(app.readString(qs, longParam, "10y"))
+	input.Short = data.TreasuryMaturity(app.readString(qs, shortParam, "2y"))
+	input.DateRange = app.readDateRange(qs, data.Unknown, v)
+
+	longIndex := data.TreasuryMaturityIndex(input.Long)
+	shortIndex := data.TreasuryMaturityIndex(input.Short)
+	v.Check(longIndex >= 0, longParam, "must be one of 3m, 2y, 5y, 7y, 10y or 30y")
+	v.Check(shortIndex >= 0, shortParam, "must be one of 3m, 2y, 5y, 7y, 10y or 30y")
+	v.Check(longIndex > shortIndex, longParam, "must be a longer maturity than short")
+	data.ValidateDateRange(v, input.DateRange)
+	if !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	spread, err := app.services.TreasuryService.YieldSpread(r.Context(), input.Long, input.Short, input.DateRange)
+	if err != nil {
+		utils.Logger(r.Context()).Error("treasuryYieldSpreadHandler error getting spread", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.WriteJson(w, http.StatusOK, envelope{
+		"data": spread,
+		"meta": map[string]interface{}{
+			"from": input.DateRange.From,
+			"to":   input.DateRange.To,
+		},
+	}, nil)
+	if err != nil {
+		utils.Logger(r.Context()).Error("treasuryYieldSpreadHandler error writing json", zap.Error(err))
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -8,6 +8,7 @@ require (
 	github.com/common-nighthawk/go-figure v0.0.0-20210622060536-734e95fb86be
 	github.com/go-mail/mail/v2 v2.3.0
 	github.com/golang-migrate/migrate v3.5.4+incompatible
+	github.com/graphql-go/graphql v0.8.1
 	github.com/jmoiron/sqlx v1.3.5
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/lib/pq v1.10.6
@@ -23,7 +24,19 @@ require (
 
 require github.com/google/uuid v1.3.0
 
-require github.com/mhamm84/gofinance-alpha v0.0.0-20220823160652-988b7e17a0a7
+require (
+	github.com/andybalholm/brotli v1.0.4
+	github.com/klauspost/compress v1.15.9
+	github.com/mhamm84/gofinance-alpha v0.0.0-20220823160652-988b7e17a0a7
+	google.golang.org/grpc v1.49.0
+	google.golang.org/protobuf v1.28.1
+)
+
+require (
+	github.com/golang/protobuf v1.5.2 // indirect
+	golang.org/x/net v0.0.0-20220520000938-2e3eb7b945c2 // indirect
+	google.golang.org/genproto v0.0.0-20220519153652-3a47de7e79bd // indirect
+)
 
 require (
 	github.com/Microsoft/go-winio v0.5.2 // indirect
@@ -0,0 +1,29 @@
+package data
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// AnomalyScore is how unusual an observation's change from the previous observation is, Score is the robust z-score
+// against the trailing window of changes and SeasonalScore against the changes in the same month of prior years
+type AnomalyScore struct {
+	Score         decimal.Decimal     `db:"score" json:"score"`
+	SeasonalScore decimal.NullDecimal `db:"seasonal_score" json:"seasonalScore"`
+	Outlier       bool                `db:"outlier" json:"outlier"`
+}
+
+// Anomaly is the score of an observation scored when it was synced
+type Anomaly struct {
+	Slug       string          `db:"slug" json:"slug"`
+	Date       time.Time       `db:"time" json:"date"`
+	Value      decimal.Decimal `db:"value" json:"value"`
+	Change     decimal.Decimal `db:"change" json:"change"`
+	DetectedAt time.Time       `db:"detected_at" json:"detectedAt"`
+	AnomalyScore
+}
+
+type AnomalyResult struct {
+	Data *[]Anomaly
+	Meta *Metadata
+}
@@ -0,0 +1,37 @@
+package data
+
+import "time"
+
+// Coverage is the span of the data held for a series, From and To are nil when there are no observations
+type Coverage struct {
+	From         *time.Time `db:"from_date" json:"from"`
+	To           *time.Time `db:"to_date" json:"to"`
+	Observations int        `db:"observations" json:"observations"`
+}
+
+// CatalogEntry describes a report the API serves, combining the economic_report row with the data held for it
+type CatalogEntry struct {
+	Slug         string    `json:"slug"`
+	DisplayName  string    `json:"displayName"`
+	Description  string    `json:"description"`
+	Unit         string    `json:"unit"`
+	Frequency    string    `json:"frequency"`
+	Image        string    `json:"image"`
+	Coverage     Coverage  `json:"coverage"`
+	LastSyncDate time.Time `json:"lastSyncDate"`
+	Extras       Extras    `json:"extras,omitempty"`
+}
+
+func NewCatalogEntry(report Report, reportType ReportType, coverage Coverage) CatalogEntry {
+	return CatalogEntry{
+		Slug:         report.Slug,
+		DisplayName:  report.DisplayName,
+		Description:  report.Description,
+		Unit:         report.Unit,
+		Frequency:    reportType.Frequency(),
+		Image:        report.Image,
+		Coverage:     coverage,
+		LastSyncDate: report.LastPullDate,
+		Extras:       report.Extras,
+	}
+}
@@ -0,0 +1,51 @@
+package data
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Alignment is the policy used to line up several series on their common dates
+type Alignment string
+
+const (
+	AlignInner       Alignment = "inner"
+	AlignOuter       Alignment = "outer"
+	AlignForwardFill Alignment = "ffill"
+)
+
+func (a Alignment) Valid() bool {
+	switch a {
+	case AlignInner, AlignOuter, AlignForwardFill:
+		return true
+	default:
+		return false
+	}
+}
+
+type ComparisonRow struct {
+	Date   time.Time                   `json:"date"`
+	Values map[string]*decimal.Decimal `json:"values"`
+}
+
+// CorrelationMethod is the correlation coefficient calculated between each pair of series
+type CorrelationMethod string
+
+const (
+	CorrelationPearson  CorrelationMethod = "pearson"
+	CorrelationSpearman CorrelationMethod = "spearman"
+)
+
+func (m CorrelationMethod) Valid() bool {
+	return m == CorrelationPearson || m == CorrelationSpearman
+}
+
+// CorrelationMatrix holds the coefficient and the number of overlapping observations for each pair of series,
+// indexed in the order of Series, a coefficient is nil when there are too few overlapping observations
+type CorrelationMatrix struct {
+	Series       []string          `json:"series"`
+	Method       CorrelationMethod `json:"method"`
+	Frequency    Frequency         `json:"frequency"`
+	Coefficients [][]*float64      `json:"coefficients"`
+	Observations [][]int           `json:"observations"`
+}
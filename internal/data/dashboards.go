@@ -0,0 +1,82 @@
+package data
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const MaxDashboardItems = 20
+
+// DashboardItem is a series shown on a dashboard with the change it is shown with, Name is the header of its summary
+// and defaults to the name of the report
+type DashboardItem struct {
+	Series string     `json:"series"`
+	Change ChangeType `json:"change"`
+	Name   string     `json:"name,omitempty"`
+}
+
+// DashboardItems are stored as a JSON array, the order of the items is the order the summaries are shown in
+type DashboardItems []DashboardItem
+
+func (d DashboardItems) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *DashboardItems) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, d)
+}
+
+// Dashboard is a user's choice of series for their summary, the default dashboard isn't stored and has an ID of 0
+type Dashboard struct {
+	ID        int64          `db:"id" json:"id"`
+	UserID    int64          `db:"user_id" json:"-"`
+	Name      string         `db:"name" json:"name"`
+	Items     DashboardItems `db:"items" json:"items"`
+	CreatedAt time.Time      `db:"created_at" json:"createdAt"`
+	Version   int            `db:"version" json:"version"`
+}
+
+// ValidateDashboard checks the name and items, the items' series are resolved through the registry by the caller
+func ValidateDashboard(v *validator.Validator, d *Dashboard) {
+	v.Check(d.Name != "", "name", "must be provided")
+	v.Check(len(d.Name) <= 100, "name", "must not be more than 100 bytes long")
+	v.Check(len(d.Items) > 0, "items", "must contain at least one item")
+	v.Check(len(d.Items) <= MaxDashboardItems, "items", fmt.Sprintf("must contain at most %d items", MaxDashboardItems))
+
+	series := make([]string, 0, len(d.Items))
+	for _, item := range d.Items {
+		series = append(series, item.Series)
+		switch item.Change {
+		case ChangePeriodOverPeriod, ChangeYearOverYear, ChangeAnnualized:
+		default:
+			v.AddError("items", "must each have a change of pop, yoy or annualized")
+		}
+		v.Check(len(item.Name) <= 100, "items", "must each have a name of at most 100 bytes")
+	}
+	v.Check(validator.Unique(series), "items", "must not contain duplicate series")
+}
+
+type DashboardRepository interface {
+	// Insert stores the dashboard, setting its ID, CreatedAt and Version
+	Insert(ctx context.Context, dashboard *Dashboard) error
+	// Get gets a dashboard of the user, ErrRecordNotFound when it doesn't exist or belongs to another user
+	Get(ctx context.Context, id, userID int64) (*Dashboard, error)
+	// GetAllForUser gets the dashboards of the user, oldest first
+	GetAllForUser(ctx context.Context, userID int64) (*[]Dashboard, error)
+	// Update stores the name and items of the dashboard, ErrEditConflict when its version has changed since it was read
+	Update(ctx context.Context, dashboard *Dashboard) error
+	// Delete deletes a dashboard of the user, ErrRecordNotFound when it doesn't exist or belongs to another user
+	Delete(ctx context.Context, id, userID int64) error
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestValidateDashboard(t *testing.T) {
+	cpi := DashboardItem{Series: "cpi", Change: ChangePeriodOverPeriod}
+	gdp := DashboardItem{Series: "real_gdp", Change: ChangeYearOverYear, Name: "GDP"}
+	tooMany := make(DashboardItems, MaxDashboardItems+1)
+	for i := range tooMany {
+		tooMany[i] = DashboardItem{Series: strings.Repeat("s", i+1), Change: ChangePeriodOverPeriod}
+	}
+
+	tests := []struct {
+		name      string
+		dashboard Dashboard
+		want      bool
+	}{
+		{name: "Valid", dashboard: Dashboard{Name: "Growth", Items: DashboardItems{cpi, gdp}}, want: true},
+		{name: "Missing Name", dashboard: Dashboard{Items: DashboardItems{cpi}}, want: false},
+		{name: "Long Name", dashboard: Dashboard{Name: strings.Repeat("a", 101), Items: DashboardItems{cpi}}, want: false},
+		{name: "No Items", dashboard: Dashboard{Name: "Growth"}, want: false},
+		{name: "Too Many Items", dashboard: Dashboard{Name: "Growth", Items: tooMany}, want: false},
+		{name: "Duplicate Series", dashboard: Dashboard{Name: "Growth", Items: DashboardItems{cpi, cpi}}, want: false},
+		{name: "Unknown Change", dashboard: Dashboard{Name: "Growth", Items: DashboardItems{{Series: "cpi", Change: "mom"}}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			ValidateDashboard(v, &tt.dashboard)
+			assert.Equal(t, tt.want, v.Valid())
+		})
+	}
+}
+
+func TestDashboardItems_Scan(t *testing.T) {
+	items := DashboardItems{{Series: "cpi", Change: ChangeYearOverYear, Name: "CPI"}, {Series: "real_gdp", Change: ChangeAnnualized}}
+	value, err := items.Value()
+	assert.NoError(t, err)
+
+	var scanned DashboardItems
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, items, scanned)
+}
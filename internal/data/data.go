@@ -6,4 +6,5 @@ var (
 	ErrRecordNotFound = errors.New("record not found")
 	ErrEditConflict   = errors.New("edit conflict")
 	ErrDuplicateEmail = errors.New("duplicate email")
+	ErrNotEnoughData  = errors.New("not enough data")
 )
@@ -14,6 +14,7 @@ type Report struct {
 	Slug                    string    `db:"slug" json:"slug"`
 	DisplayName             string    `db:"display_name" json:"displayName"`
 	Description             string    `db:"description" json:"description"`
+	Unit                    string    `db:"unit" json:"unit"`
 	Image                   string    `db:"image" json:"image"`
 	LastPullDate            time.Time `db:"last_data_pull" json:"lastPullDate"`
 	InitialSyncDelayMinutes int       `db:"initial_sync_delay_minutes" json:"initialSyncDelayMinutes"`
@@ -61,170 +62,175 @@ const (
 	Unknown
 )
 
+// reportTypeInfo describes a ReportType, the table is also the slug of the report in the economic_report table
+type reportTypeInfo struct {
+	name      string
+	table     string
+	frequency string
+	maturity  TreasuryMaturity
+}
+
+// reportTypes is indexed by ReportType, adding an indicator means adding its constant and an entry here
+var reportTypes = [...]reportTypeInfo{
+	CPI:                     {name: "CPI", table: "cpi", frequency: "monthly"},
+	ConsumerSentiment:       {name: "CONSUMER_SENTIMENT", table: "consumer_sentiment", frequency: "monthly"},
+	RetailSales:             {name: "RETAIL_SALES", table: "retail_sales", frequency: "monthly"},
+	TreasuryYieldThreeMonth: {name: "TREASURY_YIELD_THREE_MONTH", table: "treasury_yield_three_month", frequency: "daily", maturity: "3m"},
+	TreasuryYieldTwoYear:    {name: "TREASURY_YIELD_TWO_YEAR", table: "treasury_yield_two_year", frequency: "daily", maturity: "2y"},
+	TreasuryYieldFiveYear:   {name: "TREASURY_YIELD_FIVE_YEAR", table: "treasury_yield_five_year", frequency: "daily", maturity: "5y"},
+	TreasuryYieldSevenYear:  {name: "TREASURY_YIELD_SEVEN_YEAR", table: "treasury_yield_seven_year", frequency: "daily", maturity: "7y"},
+	TreasuryYieldTenYear:    {name: "TREASURY_YIELD_TEN_YEAR", table: "treasury_yield_ten_year", frequency: "daily", maturity: "10y"},
+	TreasuryYieldThirtyYear: {name: "TREASURY_YIELD_THIRTY_YEAR", table: "treasury_yield_thirty_year", frequency: "daily", maturity: "30y"},
+	RealGDP:                 {name: "REAL_GDP", table: "real_gdp", frequency: "quarterly"},
+	RealGdpPerCapita:        {name: "REAL_GDP_PER_CAPITA", table: "real_gdp_per_capita", frequency: "quarterly"},
+	FederalFundsRate:        {name: "FEDERAL_FUNDS_RATE", table: "federal_funds_rate", frequency: "monthly"},
+	DurableGoodsOrders:      {name: "DURABLE_GOODS_ORDERS", table: "durable_goods_orders", frequency: "monthly"},
+	Unemployment:            {name: "UNEMPLOYMENT", table: "unemployment", frequency: "monthly"},
+	NonfarmPayroll:          {name: "NONFARM_PAYROLL", table: "nonfarm_payrolls", frequency: "monthly"},
+	Inflation:               {name: "INFLATION", table: "inflation", frequency: "annual"},
+	InflationExpectation:    {name: "INFLATION_EXPECTATION", table: "inflation_expectation", frequency: "monthly"},
+}
+
+// ReportTypes are all the known report types, in the order of their constants
+func ReportTypes() []ReportType {
+	res := make([]ReportType, 0, len(reportTypes))
+	for r := range reportTypes {
+		res = append(res, ReportType(r))
+	}
+	return res
+}
+
+func (r ReportType) info() (reportTypeInfo, bool) {
+	if r < 0 || int(r) >= len(reportTypes) {
+		return reportTypeInfo{}, false
+	}
+	return reportTypes[r], true
+}
+
 func ReportTypeTreasuryYieldMaturity(maturity string) ReportType {
-	switch maturity {
-	case "3m":
-		return TreasuryYieldThreeMonth
-	case "2y":
-		return TreasuryYieldTwoYear
-	case "5y":
-		return TreasuryYieldFiveYear
-	case "7y":
-		return TreasuryYieldSevenYear
-	case "10y":
-		return TreasuryYieldTenYear
-	case "30y":
-		return TreasuryYieldThirtyYear
-	default:
-		return Unknown
+	for r, info := range reportTypes {
+		if info.maturity != "" && string(info.maturity) == maturity {
+			return ReportType(r)
+		}
 	}
+	return Unknown
 }
 
 func MaturityFromReportType(report ReportType) TreasuryMaturity {
-	switch report {
-	case TreasuryYieldThreeMonth:
-		return "3m"
-	case TreasuryYieldTwoYear:
-		return "2y"
-	case TreasuryYieldFiveYear:
-		return "5y"
-	case TreasuryYieldSevenYear:
-		return "7y"
-	case TreasuryYieldTenYear:
-		return "10y"
-	case TreasuryYieldThirtyYear:
-		return "30y"
-	default:
-		return "Unknown"
+	if info, ok := report.info(); ok && info.maturity != "" {
+		return info.maturity
 	}
+	return "Unknown"
 }
 
-func (r ReportType) String() string {
-	switch r {
-	case CPI:
-		return "CPI"
-	case ConsumerSentiment:
-		return "CONSUMER_SENTIMENT"
-	case RetailSales:
-		return "RETAIL_SALES"
-	case TreasuryYieldThreeMonth:
-		return "TREASURY_YIELD_THREE_MONTH"
-	case TreasuryYieldTwoYear:
-		return "TREASURY_YIELD_TWO_YEAR"
-	case TreasuryYieldFiveYear:
-		return "TREASURY_YIELD_FIVE_YEAR"
-	case TreasuryYieldSevenYear:
-		return "TREASURY_YIELD_SEVEN_YEAR"
-	case TreasuryYieldTenYear:
-		return "TREASURY_YIELD_TEN_YEAR"
-	case TreasuryYieldThirtyYear:
-		return "TREASURY_YIELD_THIRTY_YEAR"
-	case RealGDP:
-		return "REAL_GDP"
-	case RealGdpPerCapita:
-		return "REAL_GDP_PER_CAPITA"
-	case FederalFundsRate:
-		return "FEDERAL_FUNDS_RATE"
-	case DurableGoodsOrders:
-		return "DURABLE_GOODS_ORDERS"
-	case Unemployment:
-		return "UNEMPLOYMENT"
-	case NonfarmPayroll:
-		return "NONFARM_PAYROLL"
-	case Inflation:
-		return "INFLATION"
-	case InflationExpectation:
-		return "INFLATION_EXPECTATION"
-	default:
-		return "Unknown"
+// Frequency is how often the report is published, one of daily, monthly, quarterly or annual
+func (r ReportType) Frequency() string {
+	if info, ok := r.info(); ok {
+		return info.frequency
 	}
+	return ""
 }
 
-type tableName string
+func (r ReportType) String() string {
+	if info, ok := r.info(); ok {
+		return info.name
+	}
+	return "Unknown"
+}
 
 func TableFromReportType(reportType ReportType) string {
-	switch reportType {
-	case CPI:
-		return string(cpiTableName)
-	case ConsumerSentiment:
-		return string(consumerSentimentTableName)
-	case RetailSales:
-		return string(retailSalesTableName)
-	case TreasuryYieldThreeMonth:
-		return string(treasuryYieldThreeMonthTableName)
-	case TreasuryYieldTwoYear:
-		return string(treasuryYieldTwoYearTableName)
-	case TreasuryYieldFiveYear:
-		return string(treasuryYieldFiveYearTableName)
-	case TreasuryYieldSevenYear:
-		return string(treasuryYieldSevenYearTableName)
-	case TreasuryYieldTenYear:
-		return string(treasuryYieldTenYearTableName)
-	case TreasuryYieldThirtyYear:
-		return string(treasuryYieldThirtyYearTableName)
-	case RealGDP:
-		return string(realGdpTableName)
-	case RealGdpPerCapita:
-		return string(realGdpPerCapitaTableName)
-	case FederalFundsRate:
-		return string(federalFundsRateTableName)
-	case DurableGoodsOrders:
-		return string(durableGoodsOrdersTableName)
-	case Unemployment:
-		return string(unemploymentTableName)
-	case NonfarmPayroll:
-		return string(nonfarmPayrollsTableName)
-	case Inflation:
-		return string(inflationTableName)
-	case InflationExpectation:
-		return string(inflationExpectationTableName)
-	default:
-		return "unknown"
+	if info, ok := reportType.info(); ok {
+		return info.table
 	}
+	return "unknown"
 }
 
-const (
-	cpiTableName                     tableName = "cpi"
-	consumerSentimentTableName       tableName = "consumer_sentiment"
-	retailSalesTableName             tableName = "retail_sales"
-	treasuryYieldThreeMonthTableName tableName = "treasury_yield_three_month"
-	treasuryYieldTwoYearTableName    tableName = "treasury_yield_two_year"
-	treasuryYieldFiveYearTableName   tableName = "treasury_yield_five_year"
-	treasuryYieldSevenYearTableName  tableName = "treasury_yield_seven_year"
-	treasuryYieldTenYearTableName    tableName = "treasury_yield_ten_year"
-	treasuryYieldThirtyYearTableName tableName = "treasury_yield_thirty_year"
-	realGdpTableName                 tableName = "real_gdp"
-	realGdpPerCapitaTableName        tableName = "real_gdp_per_capita"
-	federalFundsRateTableName        tableName = "federal_funds_rate"
-	durableGoodsOrdersTableName      tableName = "durable_goods_orders"
-	unemploymentTableName            tableName = "unemployment"
-	nonfarmPayrollsTableName         tableName = "nonfarm_payrolls"
-	inflationTableName               tableName = "inflation"
-	inflationExpectationTableName    tableName = "inflation_expectation"
-)
-
 func (r ReportType) ToTable() string {
 	return TableFromReportType(r)
 }
 
+// ReportTypeFromSlug resolves a report slug, which is also its table name, to the ReportType
+func ReportTypeFromSlug(slug string) ReportType {
+	for r, info := range reportTypes {
+		if info.table == slug {
+			return ReportType(r)
+		}
+	}
+	return Unknown
+}
+
 type Economic struct {
 	Date  time.Time       `db:"time" json:"date"`
 	Value decimal.Decimal `db:"value" json:"value"`
 }
 
+// EconomicWithChange is an observation with its change. Anomaly is the score of the current observation, it's left
+// out of resampled periods and asOf queries
 type EconomicWithChange struct {
-	Date   time.Time       `db:"time" json:"date"`
-	Value  decimal.Decimal `db:"value" json:"value"`
-	Change decimal.Decimal `db:"percentage_change" json:"change"`
+	Date    time.Time           `db:"time" json:"date"`
+	Value   decimal.Decimal     `db:"value" json:"value"`
+	Change  decimal.NullDecimal `db:"percentage_change" json:"change"`
+	Anomaly *AnomalyScore       `db:"-" json:"anomaly,omitempty"`
 }
 
+// EconomicStats are the metrics of a time bucket, a metric is nil when it wasn't asked for or can't be calculated
+// for the bucket, e.g. the stddev of a single observation. Kurtosis is the non-excess kurtosis
 type EconomicStats struct {
-	StartDate time.Time       `db:"start_date" json:"from"`
-	EndDate   time.Time       `db:"end_date" json:"to"`
-	Stddev    decimal.Decimal `db:"stddev" json:"stddev"`
-	Mean      decimal.Decimal `db:"mean" json:"mean"`
-	Min       decimal.Decimal `db:"min" json:"min"`
-	Max       decimal.Decimal `db:"max" json:"max"`
+	StartDate time.Time        `db:"start_date" json:"from"`
+	EndDate   time.Time        `db:"end_date" json:"to"`
+	Stddev    *decimal.Decimal `db:"stddev" json:"stddev,omitempty"`
+	Mean      *decimal.Decimal `db:"mean" json:"mean,omitempty"`
+	Min       *decimal.Decimal `db:"min" json:"min,omitempty"`
+	Max       *decimal.Decimal `db:"max" json:"max,omitempty"`
+	Median    *decimal.Decimal `db:"median" json:"median,omitempty"`
+	P10       *decimal.Decimal `db:"p10" json:"p10,omitempty"`
+	P25       *decimal.Decimal `db:"p25" json:"p25,omitempty"`
+	P75       *decimal.Decimal `db:"p75" json:"p75,omitempty"`
+	P90       *decimal.Decimal `db:"p90" json:"p90,omitempty"`
+	First     *decimal.Decimal `db:"first" json:"first,omitempty"`
+	Last      *decimal.Decimal `db:"last" json:"last,omitempty"`
+	Count     *int64           `db:"count" json:"count,omitempty"`
+	Skewness  *decimal.Decimal `db:"skewness" json:"skewness,omitempty"`
+	Kurtosis  *decimal.Decimal `db:"kurtosis" json:"kurtosis,omitempty"`
+}
+
+// Metric is the value of the metric as a decimal, the count is converted
+func (e EconomicStats) Metric(metric StatsMetric) *decimal.Decimal {
+	switch metric {
+	case StatsStddev:
+		return e.Stddev
+	case StatsMean:
+		return e.Mean
+	case StatsMin:
+		return e.Min
+	case StatsMax:
+		return e.Max
+	case StatsMedian:
+		return e.Median
+	case StatsP10:
+		return e.P10
+	case StatsP25:
+		return e.P25
+	case StatsP75:
+		return e.P75
+	case StatsP90:
+		return e.P90
+	case StatsFirst:
+		return e.First
+	case StatsLast:
+		return e.Last
+	case StatsCount:
+		if e.Count == nil {
+			return nil
+		}
+		count := decimal.NewFromInt(*e.Count)
+		return &count
+	case StatsSkewness:
+		return e.Skewness
+	case StatsKurtosis:
+		return e.Kurtosis
+	}
+	return nil
 }
 
 type EconomicWithChangeResult struct {
@@ -237,13 +243,46 @@ type EconomicStatsResult struct {
 	Meta *Metadata
 }
 
+// RollingStats are the trailing window statistics of an observation, a statistic is nil when it wasn't requested
+// or the window isn't full yet. The ZScore is against the window of observations before this one
+type RollingStats struct {
+	Date   time.Time        `json:"date"`
+	Value  decimal.Decimal  `json:"value"`
+	Mean   *decimal.Decimal `json:"mean,omitempty"`
+	Stddev *decimal.Decimal `json:"stddev,omitempty"`
+	ZScore *decimal.Decimal `json:"zscore,omitempty"`
+}
+
+type RollingResult struct {
+	Data *[]RollingStats
+	Meta *Metadata
+}
+
 type EconomicRepository interface {
-	LatestWithPercentChange(ctx context.Context, table string) (*EconomicWithChange, error)
-	GetIntervalWithPercentChange(ctx context.Context, table string, years int, paging Paging) (*EconomicWithChangeResult, error)
-	GetStats(ctx context.Context, table string, years int, timeBucketDays int, paging Paging) (*EconomicStatsResult, error)
+	LatestWithPercentChange(ctx context.Context, table string, change Change) (*EconomicWithChange, error)
+	GetIntervalWithPercentChange(ctx context.Context, table string, filter SeriesFilter, paging Paging) (*EconomicWithChangeResult, error)
+	// EachWithPercentChange calls fn with each observation of the filter, newest first, as the rows of a single query
+	// are read. An error returned by fn stops the query and is returned
+	EachWithPercentChange(ctx context.Context, table string, filter SeriesFilter, fn func(economic EconomicWithChange) error) error
+	// EachPageWithPercentChange calls start with the metadata of the page, then fn with each observation of the page as
+	// the rows of the query are read. start is called even when the page is empty, an error returned by either stops the
+	// query and is returned
+	EachPageWithPercentChange(ctx context.Context, table string, filter SeriesFilter, paging Paging, start func(meta Metadata) error, fn func(economic EconomicWithChange) error) error
+	GetStats(ctx context.Context, table string, dateRange DateRange, timeBucketDays int, metrics StatsMetrics, paging Paging) (*EconomicStatsResult, error)
+	// EachStats calls start with the metadata of the page, then fn with each bucket of the page, as EachPageWithPercentChange
+	EachStats(ctx context.Context, table string, dateRange DateRange, timeBucketDays int, metrics StatsMetrics, paging Paging, start func(meta Metadata) error, fn func(stats EconomicStats) error) error
 	GetAll(ctx context.Context, table string) (*[]Economic, error)
+	GetRange(ctx context.Context, table string, filter SeriesFilter) (*[]Economic, error)
+	GetLatestOnOrBefore(ctx context.Context, table string, date time.Time) (*Economic, error)
+	GetRolling(ctx context.Context, table string, filter SeriesFilter, window RollingWindow, paging Paging) (*RollingResult, error)
+	GetCoverage(ctx context.Context, table string) (*Coverage, error)
+	GetVintages(ctx context.Context, table string, date time.Time) (*[]Vintage, error)
+	GetRevisions(ctx context.Context, table string, since time.Time, paging Paging) (*RevisionSummaryResult, error)
 	Insert(ctx context.Context, table string, data *Economic) error
 	InsertMany(ctx context.Context, table string, data *[]Economic) error
+	Revise(ctx context.Context, table string, data *Economic) error
+	InsertAnomaly(ctx context.Context, anomaly *Anomaly) error
+	GetAnomalies(ctx context.Context, since time.Time, paging Paging) (*AnomalyResult, error)
 }
 
 type ReportRepository interface {
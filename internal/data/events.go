@@ -0,0 +1,39 @@
+package data
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type EconomicEventKind string
+
+const (
+	EventInserted EconomicEventKind = "inserted"
+	EventRevised  EconomicEventKind = "revised"
+)
+
+// EconomicEvent is an observation inserted or revised by the data sync, the ID orders the events of every series and
+// is the id of the server-sent event, so a client reconnecting with it as the Last-Event-ID gets the events after it
+type EconomicEvent struct {
+	ID        int64             `db:"id" json:"id"`
+	Slug      string            `db:"slug" json:"slug"`
+	Kind      EconomicEventKind `db:"kind" json:"kind"`
+	Date      time.Time         `db:"time" json:"date"`
+	Value     decimal.Decimal   `db:"value" json:"value"`
+	CreatedAt time.Time         `db:"created_at" json:"createdAt"`
+}
+
+type EventRepository interface {
+	// Insert stores the event, setting its ID and CreatedAt, and notifies every EventListener
+	Insert(ctx context.Context, event *EconomicEvent) error
+	// GetAfter gets the events of the series after the id, oldest first
+	GetAfter(ctx context.Context, id int64, slugs []string, limit int) (*[]EconomicEvent, error)
+	// GetLatestID gets the id of the latest event, 0 when there are no events
+	GetLatestID(ctx context.Context) (int64, error)
+}
+
+// EventListener delivers the events inserted by every replica of the API
+type EventListener interface {
+	Listen(ctx context.Context, handle func(event EconomicEvent)) error
+}
@@ -3,6 +3,7 @@ package data
 import (
 	"github.com/mhamm84/pulse-api/internal/validator"
 	"math"
+	"time"
 )
 
 type Paging struct {
@@ -25,6 +26,235 @@ func ValidatePaging(v *validator.Validator, p Paging) {
 	v.Check(p.PageSize <= 100, "page_size", "must be a maximum of 100")
 }
 
+// DateRange is an inclusive window of observation dates used to filter economic series, when AsOf is set
+// the observations are the vintage known on that date rather than the latest values
+type DateRange struct {
+	From time.Time
+	To   time.Time
+	AsOf time.Time
+}
+
+// PointInTime reports whether the range asks for a vintage of the data rather than the latest values
+func (d DateRange) PointInTime() bool {
+	return !d.AsOf.IsZero()
+}
+
+// DateRangeFromYears builds the range covering the given number of years back from now
+func DateRangeFromYears(years int, now time.Time) DateRange {
+	return DateRange{
+		From: now.AddDate(-years, 0, 0),
+		To:   now,
+	}
+}
+
+func ValidateDateRange(v *validator.Validator, d DateRange) {
+	v.Check(!d.To.IsZero(), "to", "must be provided")
+	v.Check(!d.From.After(d.To), "from", "must be on or before to")
+	v.Check(!d.AsOf.After(time.Now()), "asOf", "must not be in the future")
+}
+
+// Frequency is the calendar period a series is resampled to, the zero value leaves the series at its native frequency
+type Frequency string
+
+const (
+	FrequencyNative    Frequency = ""
+	FrequencyWeekly    Frequency = "weekly"
+	FrequencyMonthly   Frequency = "monthly"
+	FrequencyQuarterly Frequency = "quarterly"
+	FrequencyAnnual    Frequency = "annual"
+)
+
+// Interval is the Postgres interval of the bucket, TimescaleDB aligns month and year buckets to the calendar
+func (f Frequency) Interval() string {
+	switch f {
+	case FrequencyWeekly:
+		return "1 week"
+	case FrequencyMonthly:
+		return "1 month"
+	case FrequencyQuarterly:
+		return "3 months"
+	case FrequencyAnnual:
+		return "1 year"
+	default:
+		return ""
+	}
+}
+
+// Aggregation is how the observations within a resampled bucket are reduced to a single value
+type Aggregation string
+
+const (
+	AggregationLast  Aggregation = "last"
+	AggregationFirst Aggregation = "first"
+	AggregationMean  Aggregation = "mean"
+	AggregationMin   Aggregation = "min"
+	AggregationMax   Aggregation = "max"
+)
+
+// SQL is the aggregate expression over the value and time columns of an economic table
+func (a Aggregation) SQL() string {
+	switch a {
+	case AggregationFirst:
+		return "first(value, time)"
+	case AggregationMean:
+		return "avg(value)"
+	case AggregationMin:
+		return "min(value)"
+	case AggregationMax:
+		return "max(value)"
+	default:
+		return "last(value, time)"
+	}
+}
+
+// ChangeType is how the change of each observation is calculated
+type ChangeType string
+
+const (
+	ChangePeriodOverPeriod ChangeType = "pop"
+	ChangeYearOverYear     ChangeType = "yoy"
+	ChangeAnnualized       ChangeType = "annualized"
+)
+
+// Change selects the change calculation, Lag is the number of observations back the pop and annualized
+// changes are calculated against
+type Change struct {
+	Type ChangeType
+	Lag  int
+}
+
+var DefaultChange = Change{Type: ChangePeriodOverPeriod, Lag: 1}
+
+func ValidateChange(v *validator.Validator, c Change) {
+	switch c.Type {
+	case ChangePeriodOverPeriod, ChangeYearOverYear, ChangeAnnualized:
+	default:
+		v.AddError("change", "must be one of pop, yoy or annualized")
+	}
+	v.Check(c.Lag > 0, "lag", "must be greater than zero")
+	v.Check(c.Lag <= 1000, "lag", "must be a maximum of 1000")
+}
+
+// SeriesFilter selects and shapes the observations returned for a series
+type SeriesFilter struct {
+	DateRange   DateRange
+	Frequency   Frequency
+	Aggregation Aggregation
+	Change      Change
+}
+
+func (f SeriesFilter) Resampled() bool {
+	return f.Frequency != FrequencyNative
+}
+
+func ValidateSeriesFilter(v *validator.Validator, f SeriesFilter) {
+	ValidateDateRange(v, f.DateRange)
+	ValidateChange(v, f.Change)
+	v.Check(f.Frequency == FrequencyNative || f.Frequency.Interval() != "", "frequency", "must be one of weekly, monthly, quarterly or annual")
+	switch f.Aggregation {
+	case AggregationLast, AggregationFirst, AggregationMean, AggregationMin, AggregationMax:
+	default:
+		v.AddError("agg", "must be one of last, first, mean, min or max")
+	}
+}
+
+// RollingFunction is a statistic calculated over a trailing window of observations
+type RollingFunction string
+
+const (
+	RollingMean   RollingFunction = "mean"
+	RollingStddev RollingFunction = "stddev"
+	RollingZScore RollingFunction = "zscore"
+)
+
+// RollingWindow is the number of observations in the trailing window, including the current one, and the
+// statistics to calculate over it
+type RollingWindow struct {
+	Size      int
+	Functions []RollingFunction
+}
+
+func (w RollingWindow) Includes(fn RollingFunction) bool {
+	for _, f := range w.Functions {
+		if f == fn {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateRollingWindow(v *validator.Validator, w RollingWindow) {
+	v.Check(w.Size > 1, "window", "must be greater than one")
+	v.Check(w.Size <= 1000, "window", "must be a maximum of 1000")
+	v.Check(len(w.Functions) > 0, "fn", "must be provided")
+	v.Check(validator.Unique(w.Functions), "fn", "must not contain duplicate functions")
+	for _, fn := range w.Functions {
+		switch fn {
+		case RollingMean, RollingStddev, RollingZScore:
+		default:
+			v.AddError("fn", "must be a list of mean, stddev or zscore")
+		}
+	}
+}
+
+// StatsMetric is a statistic calculated over each time bucket of a series
+type StatsMetric string
+
+const (
+	StatsStddev   StatsMetric = "stddev"
+	StatsMean     StatsMetric = "mean"
+	StatsMin      StatsMetric = "min"
+	StatsMax      StatsMetric = "max"
+	StatsMedian   StatsMetric = "median"
+	StatsP10      StatsMetric = "p10"
+	StatsP25      StatsMetric = "p25"
+	StatsP75      StatsMetric = "p75"
+	StatsP90      StatsMetric = "p90"
+	StatsFirst    StatsMetric = "first"
+	StatsLast     StatsMetric = "last"
+	StatsCount    StatsMetric = "count"
+	StatsSkewness StatsMetric = "skewness"
+	StatsKurtosis StatsMetric = "kurtosis"
+)
+
+// AllStatsMetrics are the metrics in the order they are returned
+var AllStatsMetrics = StatsMetrics{
+	StatsStddev, StatsMean, StatsMin, StatsMax, StatsMedian, StatsP10, StatsP25, StatsP75, StatsP90,
+	StatsFirst, StatsLast, StatsCount, StatsSkewness, StatsKurtosis,
+}
+
+// DefaultStatsMetrics are the metrics returned when none are asked for
+var DefaultStatsMetrics = StatsMetrics{StatsStddev, StatsMean, StatsMin, StatsMax}
+
+type StatsMetrics []StatsMetric
+
+func (m StatsMetrics) Includes(metric StatsMetric) bool {
+	for _, s := range m {
+		if s == metric {
+			return true
+		}
+	}
+	return false
+}
+
+func (m StatsMetrics) Strings() []string {
+	res := make([]string, len(m))
+	for i, metric := range m {
+		res[i] = string(metric)
+	}
+	return res
+}
+
+func ValidateStatsMetrics(v *validator.Validator, m StatsMetrics) {
+	v.Check(len(m) > 0, "metrics", "must be provided")
+	v.Check(validator.Unique(m), "metrics", "must not contain duplicate metrics")
+	for _, metric := range m {
+		if !AllStatsMetrics.Includes(metric) {
+			v.AddError("metrics", "must be a list of stddev, mean, min, max, median, p10, p25, p75, p90, first, last, count, skewness or kurtosis")
+		}
+	}
+}
+
 type Metadata struct {
 	CurrentPage  int                    `json:"current_page"`
 	PageSize     int                    `json:"page_size"`
@@ -0,0 +1,90 @@
+package data
+
+import (
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDateRangeFromYears(t *testing.T) {
+	now := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	dr := DateRangeFromYears(10, now)
+
+	assert.Equal(t, time.Date(2012, 8, 1, 0, 0, 0, 0, time.UTC), dr.From)
+	assert.Equal(t, now, dr.To)
+}
+
+func TestValidateDateRange(t *testing.T) {
+	gfcStart := time.Date(2007, 6, 1, 0, 0, 0, 0, time.UTC)
+	gfcEnd := time.Date(2009, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dateRange DateRange
+		want      bool
+	}{
+		{name: "Valid", dateRange: DateRange{From: gfcStart, To: gfcEnd}, want: true},
+		{name: "Same Day", dateRange: DateRange{From: gfcStart, To: gfcStart}, want: true},
+		{name: "Open Start", dateRange: DateRange{To: gfcEnd}, want: true},
+		{name: "From After To", dateRange: DateRange{From: gfcEnd, To: gfcStart}, want: false},
+		{name: "Missing To", dateRange: DateRange{From: gfcStart}, want: false},
+		{name: "As Of", dateRange: DateRange{From: gfcStart, To: gfcEnd, AsOf: gfcEnd}, want: true},
+		{name: "Future As Of", dateRange: DateRange{From: gfcStart, To: gfcEnd, AsOf: time.Now().AddDate(1, 0, 0)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			ValidateDateRange(v, tt.dateRange)
+			assert.Equal(t, tt.want, v.Valid())
+		})
+	}
+}
+
+func TestValidateSeriesFilter(t *testing.T) {
+	dateRange := DateRange{To: time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name   string
+		filter SeriesFilter
+		want   bool
+	}{
+		{name: "Native", filter: SeriesFilter{DateRange: dateRange, Aggregation: AggregationLast, Change: DefaultChange}, want: true},
+		{name: "Monthly Mean", filter: SeriesFilter{DateRange: dateRange, Frequency: FrequencyMonthly, Aggregation: AggregationMean, Change: DefaultChange}, want: true},
+		{name: "Unknown Frequency", filter: SeriesFilter{DateRange: dateRange, Frequency: "hourly", Aggregation: AggregationLast, Change: DefaultChange}, want: false},
+		{name: "Unknown Aggregation", filter: SeriesFilter{DateRange: dateRange, Frequency: FrequencyAnnual, Aggregation: "sum", Change: DefaultChange}, want: false},
+		{name: "YoY", filter: SeriesFilter{DateRange: dateRange, Aggregation: AggregationLast, Change: Change{Type: ChangeYearOverYear, Lag: 1}}, want: true},
+		{name: "Unknown Change", filter: SeriesFilter{DateRange: dateRange, Aggregation: AggregationLast, Change: Change{Type: "mom", Lag: 1}}, want: false},
+		{name: "Zero Lag", filter: SeriesFilter{DateRange: dateRange, Aggregation: AggregationLast, Change: Change{Type: ChangePeriodOverPeriod}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			ValidateSeriesFilter(v, tt.filter)
+			assert.Equal(t, tt.want, v.Valid())
+		})
+	}
+}
+
+func TestValidateStatsMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics StatsMetrics
+		want    bool
+	}{
+		{name: "Default", metrics: DefaultStatsMetrics, want: true},
+		{name: "All", metrics: AllStatsMetrics, want: true},
+		{name: "Quantiles", metrics: StatsMetrics{StatsP10, StatsMedian, StatsP90}, want: true},
+		{name: "Empty", metrics: StatsMetrics{}, want: false},
+		{name: "Unknown", metrics: StatsMetrics{StatsMedian, "p99"}, want: false},
+		{name: "Duplicate", metrics: StatsMetrics{StatsCount, StatsCount}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			ValidateStatsMetrics(v, tt.metrics)
+			assert.Equal(t, tt.want, v.Valid())
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"github.com/mhamm84/pulse-api/internal/validator"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// ForecastModel is the model used to forecast a series, ets is simple exponential smoothing and holt adds a trend
+type ForecastModel string
+
+const (
+	ForecastNaive ForecastModel = "naive"
+	ForecastDrift ForecastModel = "drift"
+	ForecastETS   ForecastModel = "ets"
+	ForecastHolt  ForecastModel = "holt"
+)
+
+// ForecastPoint is the point forecast for a date with its 80% and 95% prediction intervals
+type ForecastPoint struct {
+	Date    time.Time       `json:"date"`
+	Value   decimal.Decimal `json:"value"`
+	Lower80 decimal.Decimal `json:"lower80"`
+	Upper80 decimal.Decimal `json:"upper80"`
+	Lower95 decimal.Decimal `json:"lower95"`
+	Upper95 decimal.Decimal `json:"upper95"`
+}
+
+// ForecastFit is the in-sample accuracy of the one step ahead forecasts, with the smoothing parameters of the
+// ets and holt models
+type ForecastFit struct {
+	Observations int              `json:"observations"`
+	MAE          decimal.Decimal  `json:"mae"`
+	RMSE         decimal.Decimal  `json:"rmse"`
+	Alpha        *decimal.Decimal `json:"alpha,omitempty"`
+	Beta         *decimal.Decimal `json:"beta,omitempty"`
+}
+
+type Forecast struct {
+	Model   ForecastModel   `json:"model"`
+	Horizon int             `json:"horizon"`
+	Fit     ForecastFit     `json:"fit"`
+	Points  []ForecastPoint `json:"points"`
+}
+
+func ValidateForecast(v *validator.Validator, model ForecastModel, horizon int) {
+	v.Check(horizon > 0, "horizon", "must be greater than zero")
+	v.Check(horizon <= 120, "horizon", "must be a maximum of 120")
+	switch model {
+	case ForecastNaive, ForecastDrift, ForecastETS, ForecastHolt:
+	default:
+		v.AddError("model", "must be one of naive, drift, ets or holt")
+	}
+}
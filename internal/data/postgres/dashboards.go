@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/mhamm84/pulse-api/internal/data"
+)
+
+type dashboardPG struct {
+	db *sqlx.DB
+}
+
+func NewDashboardRepository(db *sqlx.DB) data.DashboardRepository {
+	return &dashboardPG{db: db}
+}
+
+func (p *dashboardPG) Insert(ctx context.Context, dashboard *data.Dashboard) error {
+	query := `
+		INSERT INTO dashboards (user_id, name, items)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, version`
+
+	args := []interface{}{dashboard.UserID, dashboard.Name, dashboard.Items}
+	return p.db.QueryRowContext(ctx, query, args...).Scan(&dashboard.ID, &dashboard.CreatedAt, &dashboard.Version)
+}
+
+func (p *dashboardPG) Get(ctx context.Context, id, userID int64) (*data.Dashboard, error) {
+	query := `
+		SELECT id, user_id, name, items, created_at, version
+		FROM dashboards
+		WHERE id = $1 AND user_id = $2`
+
+	var dashboard data.Dashboard
+	err := p.db.GetContext(ctx, &dashboard, query, id, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, data.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+	return &dashboard, nil
+}
+
+func (p *dashboardPG) GetAllForUser(ctx context.Context, userID int64) (*[]data.Dashboard, error) {
+	res := []data.Dashboard{}
+	query := `
+		SELECT id, user_id, name, items, created_at, version
+		FROM dashboards
+		WHERE user_id = $1
+		ORDER BY id`
+
+	err := p.db.SelectContext(ctx, &res, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (p *dashboardPG) Update(ctx context.Context, dashboard *data.Dashboard) error {
+	query := `
+		UPDATE dashboards
+		SET name = $1, items = $2, version = version + 1
+		WHERE id = $3 AND user_id = $4 AND version = $5
+		RETURNING version`
+
+	args := []interface{}{dashboard.Name, dashboard.Items, dashboard.ID, dashboard.UserID, dashboard.Version}
+
+	err := p.db.QueryRowContext(ctx, query, args...).Scan(&dashboard.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return data.ErrEditConflict
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *dashboardPG) Delete(ctx context.Context, id, userID int64) error {
+	query := `
+		DELETE FROM dashboards
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := p.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return data.ErrRecordNotFound
+	}
+	return nil
+}
@@ -2,9 +2,13 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"time"
 )
 
 type economicPG struct {
@@ -15,16 +19,16 @@ func NewEconomicRepository(db *sqlx.DB) data.EconomicRepository {
 	return &economicPG{db: db}
 }
 
-func (p *economicPG) LatestWithPercentChange(ctx context.Context, table string) (*data.EconomicWithChange, error) {
+func (p *economicPG) LatestWithPercentChange(ctx context.Context, table string, change data.Change) (*data.EconomicWithChange, error) {
 	res := data.EconomicWithChange{}
 	sql := fmt.Sprintf(`
 			SELECT
 		    	time,
 		    	value,
-		    	100.0 * (1 - LEAD(value) OVER (ORDER BY time desc) / value) AS percentage_change
+		    	percentage_change
 			FROM %s
 			ORDER BY time DESC
-			LIMIT 1`, table)
+			LIMIT 1`, withChange(table, change))
 
 	err := p.db.GetContext(ctx, &res, sql)
 	if err != nil {
@@ -33,19 +37,37 @@ func (p *economicPG) LatestWithPercentChange(ctx context.Context, table string)
 	return &res, nil
 }
 
-func (p *economicPG) GetStats(ctx context.Context, table string, years int, timeBucketDays int, paging data.Paging) (*data.EconomicStatsResult, error) {
+func (p *economicPG) GetStats(ctx context.Context, table string, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging) (*data.EconomicStatsResult, error) {
+	var res []data.EconomicStats
+	var meta data.Metadata
+	err := p.EachStats(ctx, table, dateRange, timeBucketDays, metrics, paging, func(m data.Metadata) error {
+		meta = m
+		return nil
+	}, func(stats data.EconomicStats) error {
+		res = append(res, stats)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &data.EconomicStatsResult{
+		Data: &res,
+		Meta: &meta,
+	}, nil
+}
+
+func (p *economicPG) EachStats(ctx context.Context, table string, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging, start func(meta data.Metadata) error, fn func(stats data.EconomicStats) error) error {
 	select {
 	default:
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return ctx.Err()
 	}
 
-	var res []data.EconomicStats
-
-	yearsParam := fmt.Sprintf("'%d year'", years)
 	// '365 days'::interval
 	timeBucketDaysParam := fmt.Sprintf("'%d days'::interval", timeBucketDays)
 
+	// The quantiles are exact, skewness and kurtosis come from the timescaledb_toolkit stats_agg
+	args := []interface{}{dateRange.From, dateRange.To, paging.Limit(), paging.Offset()}
 	query := fmt.Sprintf(`
 		SELECT
 		    count(*) OVER(),
@@ -54,76 +76,506 @@ func (p *economicPG) GetStats(ctx context.Context, table string, years int, time
     		stddev(value),
     		mean(percentile_agg(value)),
     		min(value),
-    		max(value)
+    		max(value),
+    		percentile_cont(0.5) WITHIN GROUP (ORDER BY value),
+    		percentile_cont(0.1) WITHIN GROUP (ORDER BY value),
+    		percentile_cont(0.25) WITHIN GROUP (ORDER BY value),
+    		percentile_cont(0.75) WITHIN GROUP (ORDER BY value),
+    		percentile_cont(0.9) WITHIN GROUP (ORDER BY value),
+    		first(value, time),
+    		last(value, time),
+    		count(*),
+    		skewness(stats_agg(value)),
+    		kurtosis(stats_agg(value))
 		FROM %s
-		WHERE time > NOW() - INTERVAL %s
+		WHERE time BETWEEN $1 AND $2
 		GROUP BY time_bucket(%s, time)
 		ORDER BY tMax desc
-		LIMIT $1 OFFSET $2
-		`, table, yearsParam, timeBucketDaysParam,
+		LIMIT $3 OFFSET $4
+		`, vintageSource(table, dateRange, &args), timeBucketDaysParam,
 	)
 
-	args := []interface{}{paging.Limit(), paging.Offset()}
-
 	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
-	totalRecords := 0
 
+	started := false
 	for rows.Next() {
-		var data data.EconomicStats
+		totalRecords := 0
+		var stats data.EconomicStats
+		var stddev, mean, min, max, median, p10, p25, p75, p90, first, last, skewness, kurtosis decimal.NullDecimal
+		var count int64
 		err := rows.Scan(
 			&totalRecords,
-			&data.StartDate,
-			&data.EndDate,
-			&data.Stddev,
-			&data.Mean,
-			&data.Min,
-			&data.Max,
+			&stats.StartDate,
+			&stats.EndDate,
+			&stddev,
+			&mean,
+			&min,
+			&max,
+			&median,
+			&p10,
+			&p25,
+			&p75,
+			&p90,
+			&first,
+			&last,
+			&count,
+			&skewness,
+			&kurtosis,
 		)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		stats.Stddev = statsValue(metrics, data.StatsStddev, stddev)
+		stats.Mean = statsValue(metrics, data.StatsMean, mean)
+		stats.Min = statsValue(metrics, data.StatsMin, min)
+		stats.Max = statsValue(metrics, data.StatsMax, max)
+		stats.Median = statsValue(metrics, data.StatsMedian, median)
+		stats.P10 = statsValue(metrics, data.StatsP10, p10)
+		stats.P25 = statsValue(metrics, data.StatsP25, p25)
+		stats.P75 = statsValue(metrics, data.StatsP75, p75)
+		stats.P90 = statsValue(metrics, data.StatsP90, p90)
+		stats.First = statsValue(metrics, data.StatsFirst, first)
+		stats.Last = statsValue(metrics, data.StatsLast, last)
+		stats.Skewness = statsValue(metrics, data.StatsSkewness, skewness)
+		stats.Kurtosis = statsValue(metrics, data.StatsKurtosis, kurtosis)
+		if metrics.Includes(data.StatsCount) {
+			stats.Count = &count
+		}
+		if !started {
+			if err := start(statsMetadata(totalRecords, dateRange, timeBucketDays, metrics, paging)); err != nil {
+				return err
+			}
+			started = true
+		}
+		if err := fn(stats); err != nil {
+			return err
 		}
-		res = append(res, data)
 	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	if !started {
+		return start(statsMetadata(0, dateRange, timeBucketDays, metrics, paging))
+	}
+	return nil
+}
 
+func statsMetadata(totalRecords int, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging) data.Metadata {
 	meta := data.CalculateMetadata(totalRecords, paging.Page, paging.PageSize)
 	meta.Props = map[string]interface{}{
-		"years":          years,
+		"from":           dateRange.From,
+		"to":             dateRange.To,
 		"timeBucketDays": timeBucketDays,
+		"metrics":        metrics,
 	}
-	return &data.EconomicStatsResult{
-		Data: &res,
-		Meta: &meta,
-	}, nil
+	if dateRange.PointInTime() {
+		meta.Props["asOf"] = dateRange.AsOf
+	}
+	return meta
+}
+
+func (p *economicPG) GetIntervalWithPercentChange(ctx context.Context, table string, filter data.SeriesFilter, paging data.Paging) (*data.EconomicWithChangeResult, error) {
+	res := []data.EconomicWithChange{}
+	var meta data.Metadata
+	err := p.EachPageWithPercentChange(ctx, table, filter, paging, func(m data.Metadata) error {
+		meta = m
+		return nil
+	}, func(economic data.EconomicWithChange) error {
+		res = append(res, economic)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &data.EconomicWithChangeResult{Data: &res, Meta: &meta}, nil
+}
+
+func (p *economicPG) EachPageWithPercentChange(ctx context.Context, table string, filter data.SeriesFilter, paging data.Paging, start func(meta data.Metadata) error, fn func(economic data.EconomicWithChange) error) error {
+	select {
+	default:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	args := []interface{}{filter.DateRange.From, filter.DateRange.To, paging.Limit(), paging.Offset()}
+	query := selectWithChange("count(*) OVER(),", table, filter, &args) + `
+			LIMIT $3 OFFSET $4`
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	started := false
+	for rows.Next() {
+		totalRecords := 0
+		economic, err := scanWithChange(rows, &totalRecords)
+		if err != nil {
+			return err
+		}
+		if !started {
+			if err := start(withChangeMetadata(totalRecords, filter, paging)); err != nil {
+				return err
+			}
+			started = true
+		}
+		if err := fn(economic); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	if !started {
+		return start(withChangeMetadata(0, filter, paging))
+	}
+	return nil
+}
+
+func withChangeMetadata(totalRecords int, filter data.SeriesFilter, paging data.Paging) data.Metadata {
+	meta := data.CalculateMetadata(totalRecords, paging.Page, paging.PageSize)
+	meta.Props = map[string]interface{}{
+		"change": filter.Change.Type,
+		"lag":    filter.Change.Lag,
+	}
+	if filter.Resampled() {
+		meta.Props["frequency"] = filter.Frequency
+		meta.Props["agg"] = filter.Aggregation
+	}
+	if filter.DateRange.PointInTime() {
+		meta.Props["asOf"] = filter.DateRange.AsOf
+	}
+	return meta
+}
+
+func (p *economicPG) EachWithPercentChange(ctx context.Context, table string, filter data.SeriesFilter, fn func(economic data.EconomicWithChange) error) error {
+	args := []interface{}{filter.DateRange.From, filter.DateRange.To}
+	rows, err := p.db.QueryContext(ctx, selectWithChange("", table, filter, &args), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		economic, err := scanWithChange(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(economic); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// selectWithChange selects the observations in the date range of the filter, newest first, with their change and
+// anomaly score. The dates of the range are the first two args, columns are selected before the observation's.
+//
+// The change is calculated over the whole table before filtering, so the first observation in the range is still
+// compared against the one preceding it. Anomaly scores belong to the current observations, so they aren't joined to
+// resampled periods or to the values of a past vintage
+func selectWithChange(columns, table string, filter data.SeriesFilter, args *[]interface{}) string {
+	source := withChange(seriesSource(table, filter, args), filter.Change)
+
+	anomaly, join := "NULL, NULL, NULL", ""
+	if !filter.Resampled() && !filter.DateRange.PointInTime() {
+		*args = append(*args, table)
+		anomaly = "anomaly.score, anomaly.seasonal_score, anomaly.outlier"
+		join = fmt.Sprintf(`
+			LEFT JOIN economic_anomaly anomaly
+				ON anomaly.slug = $%d AND anomaly.time = changes.time`, len(*args))
+	}
+
+	return fmt.Sprintf(`
+			SELECT
+				%s
+		    	changes.time,
+		    	changes.value,
+		    	percentage_change,
+		    	%s
+			FROM %s%s
+			WHERE changes.time BETWEEN $1 AND $2
+			ORDER BY changes.time DESC`, columns, anomaly, source, join,
+	)
+}
+
+// scanWithChange scans a row of selectWithChange into the destinations of the columns selected before the observation's
+// and the observation
+func scanWithChange(rows *sql.Rows, columns ...interface{}) (data.EconomicWithChange, error) {
+	var economic data.EconomicWithChange
+	var score, seasonalScore decimal.NullDecimal
+	var outlier *bool
+	dest := append(columns,
+		&economic.Date,
+		&economic.Value,
+		&economic.Change,
+		&score,
+		&seasonalScore,
+		&outlier,
+	)
+	if err := rows.Scan(dest...); err != nil {
+		return economic, err
+	}
+	if score.Valid {
+		economic.Anomaly = &data.AnomalyScore{Score: score.Decimal, SeasonalScore: seasonalScore, Outlier: outlier != nil && *outlier}
+	}
+	return economic, nil
+}
+
+func (p *economicPG) GetAll(ctx context.Context, table string) (*[]data.Economic, error) {
+	data := []data.Economic{}
+	err := p.db.SelectContext(ctx, &data, fmt.Sprintf(`SELECT * FROM %s ORDER BY time DESC`, table))
+	return &data, err
+}
+
+func (p *economicPG) GetRange(ctx context.Context, table string, filter data.SeriesFilter) (*[]data.Economic, error) {
+	data := []data.Economic{}
+	args := []interface{}{filter.DateRange.From, filter.DateRange.To}
+	query := fmt.Sprintf(`
+		SELECT time, value
+		FROM %s
+		WHERE time BETWEEN $1 AND $2
+		ORDER BY time DESC`, seriesSource(table, filter, &args))
+
+	err := p.db.SelectContext(ctx, &data, query, args...)
+	return &data, err
+}
+
+func (p *economicPG) GetCoverage(ctx context.Context, table string) (*data.Coverage, error) {
+	res := data.Coverage{}
+	query := fmt.Sprintf(`
+		SELECT min(time) AS from_date, max(time) AS to_date, count(*) AS observations
+		FROM %s`, table)
+
+	err := p.db.GetContext(ctx, &res, query)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
 }
 
-func (p *economicPG) GetIntervalWithPercentChange(ctx context.Context, table string, years int, paging data.Paging) (*data.EconomicWithChangeResult, error) {
+// GetVintages gets every vintage of the observation on the date, oldest first
+func (p *economicPG) GetVintages(ctx context.Context, table string, date time.Time) (*[]data.Vintage, error) {
+	res := []data.Vintage{}
+	query := `
+		SELECT value, realtime_start, realtime_end
+		FROM economic_vintage
+		WHERE slug = $1 AND time = $2
+		ORDER BY realtime_start`
+
+	err := p.db.SelectContext(ctx, &res, query, table, date)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetRevisions gets the observations revised since the date, largest revision first
+func (p *economicPG) GetRevisions(ctx context.Context, table string, since time.Time, paging data.Paging) (*data.RevisionSummaryResult, error) {
 	select {
 	default:
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
-	res := []data.EconomicWithChange{}
+	res := []data.RevisionSummary{}
 
-	yearsParam := fmt.Sprintf("'%d year'", years)
+	query := `
+		SELECT
+			count(*) OVER(),
+			time,
+			first_print,
+			current,
+			current - first_print,
+			revisions,
+			last_revised
+		FROM (
+			SELECT
+				time,
+				first(value, realtime_start) AS first_print,
+				last(value, realtime_start) AS current,
+				count(*) - 1 AS revisions,
+				max(realtime_start) AS last_revised
+			FROM economic_vintage
+			WHERE slug = $1
+			GROUP BY time
+			HAVING count(*) > 1
+		) AS revised
+		WHERE last_revised >= $2
+		ORDER BY abs(current - first_print) DESC, time DESC
+		LIMIT $3 OFFSET $4`
+	args := []interface{}{table, since, paging.Limit(), paging.Offset()}
 
-	sql := fmt.Sprintf(`
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	for rows.Next() {
+		var summary data.RevisionSummary
+		err := rows.Scan(
+			&totalRecords,
+			&summary.Date,
+			&summary.FirstPrint,
+			&summary.Current,
+			&summary.Revision,
+			&summary.Revisions,
+			&summary.LastRevised,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, summary)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	metadata := data.CalculateMetadata(totalRecords, paging.Page, paging.PageSize)
+	metadata.Props = map[string]interface{}{
+		"since": since,
+	}
+	return &data.RevisionSummaryResult{Data: &res, Meta: &metadata}, nil
+}
+
+// InsertAnomaly stores the score of an observation, replacing the previous score when a revision is rescored
+func (p *economicPG) InsertAnomaly(ctx context.Context, anomaly *data.Anomaly) error {
+	query := `
+		INSERT INTO economic_anomaly (slug, time, value, change, score, seasonal_score, outlier)
+		VALUES (:slug, :time, :value, :change, :score, :seasonal_score, :outlier)
+		ON CONFLICT (slug, time) DO UPDATE SET
+			value = EXCLUDED.value,
+			change = EXCLUDED.change,
+			score = EXCLUDED.score,
+			seasonal_score = EXCLUDED.seasonal_score,
+			outlier = EXCLUDED.outlier,
+			detected_at = NOW()`
+
+	_, err := p.db.NamedExecContext(ctx, query, *anomaly)
+	return err
+}
+
+// GetAnomalies gets the outliers of every report observed since the date, most recent first
+func (p *economicPG) GetAnomalies(ctx context.Context, since time.Time, paging data.Paging) (*data.AnomalyResult, error) {
+	select {
+	default:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	res := []data.Anomaly{}
+
+	query := `
+		SELECT count(*) OVER(), slug, time, value, change, score, seasonal_score, outlier, detected_at
+		FROM economic_anomaly
+		WHERE outlier AND time >= $1
+		ORDER BY time DESC, abs(score) DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := p.db.QueryContext(ctx, query, since, paging.Limit(), paging.Offset())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalRecords := 0
+	for rows.Next() {
+		var anomaly data.Anomaly
+		err := rows.Scan(
+			&totalRecords,
+			&anomaly.Slug,
+			&anomaly.Date,
+			&anomaly.Value,
+			&anomaly.Change,
+			&anomaly.Score,
+			&anomaly.SeasonalScore,
+			&anomaly.Outlier,
+			&anomaly.DetectedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, anomaly)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	metadata := data.CalculateMetadata(totalRecords, paging.Page, paging.PageSize)
+	metadata.Props = map[string]interface{}{
+		"since": since,
+	}
+	return &data.AnomalyResult{Data: &res, Meta: &metadata}, nil
+}
+
+func (p *economicPG) GetLatestOnOrBefore(ctx context.Context, table string, date time.Time) (*data.Economic, error) {
+	res := data.Economic{}
+	query := fmt.Sprintf(`
+		SELECT time, value
+		FROM %s
+		WHERE time <= $1
+		ORDER BY time DESC
+		LIMIT 1`, table)
+
+	err := p.db.GetContext(ctx, &res, query, date)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, data.ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+	return &res, nil
+}
+
+func (p *economicPG) GetRolling(ctx context.Context, table string, filter data.SeriesFilter, window data.RollingWindow, paging data.Paging) (*data.RollingResult, error) {
+	select {
+	default:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	res := []data.RollingStats{}
+
+	// The window frames run over the whole series before filtering, so the first observations in the
+	// range have a full window, the stats are null until the window is full. The mean and stddev are of the window
+	// ending at the observation, the z-score is against the window of observations before it so an observation
+	// doesn't dampen its own score
+	args := []interface{}{filter.DateRange.From, filter.DateRange.To, paging.Limit(), paging.Offset()}
+	query := fmt.Sprintf(`
+		SELECT
+			count(*) OVER(),
+			time,
+			value,
+			CASE WHEN n = %[1]d THEN mean END,
+			CASE WHEN n = %[1]d THEN stddev END,
+			CASE WHEN prior_n = %[1]d THEN (value - prior_mean) / NULLIF(prior_stddev, 0) END
+		FROM (
 			SELECT
-				count(*) OVER(),
-		    	time,
-		    	value,
-		    	100.0 * (1 - LEAD(value) OVER (ORDER BY time desc) / value) AS percentage_change
-			FROM %s
-			WHERE time > current_date - INTERVAL %s
-			ORDER BY time DESC
-			LIMIT $1 OFFSET $2`, table, yearsParam,
+				time,
+				value,
+				avg(value) OVER w AS mean,
+				stddev_samp(value) OVER w AS stddev,
+				count(*) OVER w AS n,
+				avg(value) OVER prior AS prior_mean,
+				stddev_samp(value) OVER prior AS prior_stddev,
+				count(*) OVER prior AS prior_n
+			FROM %[2]s
+			WINDOW
+				w AS (ORDER BY time ROWS BETWEEN %[3]d PRECEDING AND CURRENT ROW),
+				prior AS (ORDER BY time ROWS BETWEEN %[1]d PRECEDING AND 1 PRECEDING)
+		) AS rolling
+		WHERE time BETWEEN $1 AND $2
+		ORDER BY time DESC
+		LIMIT $3 OFFSET $4`, window.Size, seriesSource(table, filter, &args), window.Size-1,
 	)
-	args := []interface{}{paging.Limit(), paging.Offset()}
 
-	rows, err := p.db.QueryContext(ctx, sql, args...)
+	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,42 +583,201 @@ func (p *economicPG) GetIntervalWithPercentChange(ctx context.Context, table str
 
 	totalRecords := 0
 	for rows.Next() {
-		var economic data.EconomicWithChange
+		var stats data.RollingStats
+		var mean, stddev, zscore decimal.NullDecimal
 		err := rows.Scan(
 			&totalRecords,
-			&economic.Date,
-			&economic.Value,
-			&economic.Change,
+			&stats.Date,
+			&stats.Value,
+			&mean,
+			&stddev,
+			&zscore,
 		)
 		if err != nil {
 			return nil, err
 		}
-		res = append(res, economic)
+		stats.Mean = rollingValue(window, data.RollingMean, mean)
+		stats.Stddev = rollingValue(window, data.RollingStddev, stddev)
+		stats.ZScore = rollingValue(window, data.RollingZScore, zscore)
+		res = append(res, stats)
 	}
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+
 	metadata := data.CalculateMetadata(totalRecords, paging.Page, paging.PageSize)
+	metadata.Props = map[string]interface{}{
+		"window": window.Size,
+		"fn":     window.Functions,
+	}
+	if filter.Resampled() {
+		metadata.Props["frequency"] = filter.Frequency
+		metadata.Props["agg"] = filter.Aggregation
+	}
+	if filter.DateRange.PointInTime() {
+		metadata.Props["asOf"] = filter.DateRange.AsOf
+	}
 
-	return &data.EconomicWithChangeResult{Data: &res, Meta: &metadata}, nil
+	return &data.RollingResult{Data: &res, Meta: &metadata}, nil
 }
 
-func (p *economicPG) GetAll(ctx context.Context, table string) (*[]data.Economic, error) {
-	data := []data.Economic{}
-	err := p.db.SelectContext(ctx, &data, fmt.Sprintf(`SELECT * FROM %s ORDER BY time DESC`, table))
-	return &data, err
+func statsValue(metrics data.StatsMetrics, metric data.StatsMetric, value decimal.NullDecimal) *decimal.Decimal {
+	if !value.Valid || !metrics.Includes(metric) {
+		return nil
+	}
+	return &value.Decimal
+}
+
+func rollingValue(window data.RollingWindow, fn data.RollingFunction, value decimal.NullDecimal) *decimal.Decimal {
+	if !value.Valid || !window.Includes(fn) {
+		return nil
+	}
+	return &value.Decimal
+}
+
+// seriesSource is the FROM clause for a series, when resampling, the table is replaced by a subquery bucketing
+// the observations into calendar aligned periods of the filter frequency. The params of the clause are appended to args
+func seriesSource(table string, filter data.SeriesFilter, args *[]interface{}) string {
+	source := vintageSource(table, filter.DateRange, args)
+	if !filter.Resampled() {
+		return source
+	}
+	return fmt.Sprintf(`(
+				SELECT
+					time_bucket(INTERVAL '%s', time) AS time,
+					%s AS value
+				FROM %s
+				GROUP BY 1
+			) AS resampled`, filter.Frequency.Interval(), filter.Aggregation.SQL(), source)
+}
+
+// vintageSource is the table for the latest values, or for a point in time range a subquery of the vintages known
+// by the end of the asOf day, the vintages of an observation cover [realtime_start, realtime_end). The slug and the
+// time the vintages are known by are appended to args as the params of the subquery
+func vintageSource(table string, dateRange data.DateRange, args *[]interface{}) string {
+	if !dateRange.PointInTime() {
+		return table
+	}
+	*args = append(*args, table, dateRange.AsOf.AddDate(0, 0, 1))
+	slugParam, knownByParam := len(*args)-1, len(*args)
+	return fmt.Sprintf(`(
+				SELECT time, value
+				FROM economic_vintage
+				WHERE slug = $%[1]d
+				AND realtime_start < $%[2]d
+				AND (realtime_end IS NULL OR realtime_end >= $%[2]d)
+			) AS vintage`, slugParam, knownByParam)
+}
+
+// withChange wraps a series source in a subquery adding the percentage_change column, the previous and year ago
+// values are looked up with window functions, so the source can be a table or a resampled subquery
+func withChange(source string, change data.Change) string {
+	var changeSQL string
+	switch change.Type {
+	case data.ChangeYearOverYear:
+		changeSQL = `100.0 * (value / NULLIF(year_ago_value, 0) - 1)`
+	case data.ChangeAnnualized:
+		// Compound the change over the gap to the previous observation, using whole months for monthly and
+		// lower frequency series, so quarterly changes are raised to the 4th power, and days otherwise
+		changeSQL = `
+					CASE WHEN value / NULLIF(prev_value, 0) > 0 THEN
+						100.0 * (power(value / prev_value,
+							CASE WHEN months_between > 0 THEN 12.0 / months_between
+							ELSE 365.0 / GREATEST(EXTRACT(epoch FROM time - prev_time) / 86400, 1) END
+						) - 1)
+					END`
+	default:
+		// pop keeps the change served before the other changes were added, relative to the current value
+		changeSQL = `100.0 * (1 - prev_value / NULLIF(value, 0))`
+	}
+
+	return fmt.Sprintf(`(
+				SELECT
+					time,
+					value,
+					%s AS percentage_change
+				FROM (
+					SELECT
+						time,
+						value,
+						prev_value,
+						prev_time,
+						year_ago_value,
+						EXTRACT(year FROM age(time, prev_time)) * 12 + EXTRACT(month FROM age(time, prev_time)) AS months_between
+					FROM (
+						SELECT
+							time,
+							value,
+							LEAD(value, %d) OVER (ORDER BY time DESC) AS prev_value,
+							LEAD(time, %d) OVER (ORDER BY time DESC) AS prev_time,
+							LAST_VALUE(value) OVER (
+								ORDER BY time RANGE BETWEEN UNBOUNDED PRECEDING AND INTERVAL '1 year' PRECEDING
+							) AS year_ago_value
+						FROM %s
+					) AS lagged
+				) AS periods
+			) AS changes`, changeSQL, change.Lag, change.Lag, source)
 }
 
+// Insert adds a new observation, recording it as the first vintage of its date
 func (p *economicPG) Insert(ctx context.Context, table string, data *data.Economic) error {
 	tx := p.db.MustBeginTx(ctx, nil)
+	defer tx.Rollback()
+
 	_, err := tx.NamedExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (time, value) VALUES (:time, :value)`, table), *data)
-	tx.Commit()
-	return err
+	if err != nil {
+		return err
+	}
+	_, err = tx.NamedExecContext(ctx, insertVintage(table), *data)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (p *economicPG) InsertMany(ctx context.Context, table string, data *[]data.Economic) error {
 	tx := p.db.MustBeginTx(ctx, nil)
-	_, err := tx.NamedExec(fmt.Sprintf(`INSERT INTO %s (time, value) VALUES (:time, :value)`, table), *data)
-	tx.Commit()
-	return err
+	defer tx.Rollback()
+
+	_, err := tx.NamedExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (time, value) VALUES (:time, :value)`, table), *data)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NamedExecContext(ctx, insertVintage(table), *data)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Revise replaces the value of an existing observation, closing its current vintage and opening a new one
+// from now so the previous value is still returned for earlier asOf dates
+func (p *economicPG) Revise(ctx context.Context, table string, data *data.Economic) error {
+	tx := p.db.MustBeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	_, err := tx.NamedExecContext(ctx, fmt.Sprintf(`UPDATE %s SET value = :value WHERE time = :time`, table), *data)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE economic_vintage
+		SET realtime_end = NOW()
+		WHERE slug = $1 AND time = $2 AND realtime_end IS NULL`, table, data.Date)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NamedExecContext(ctx, insertVintage(table), *data)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertVintage opens a vintage from now, NOW() is the start of the transaction so a revision's closed
+// and opened vintages meet exactly
+func insertVintage(table string) string {
+	return fmt.Sprintf(`
+		INSERT INTO economic_vintage (slug, time, value, realtime_start)
+		VALUES ('%s', :time, :value, NOW())`, table)
 }
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/utils"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// eventChannel is the channel the economic_event insert trigger notifies
+const eventChannel = "economic_event"
+
+type eventPG struct {
+	db *sqlx.DB
+}
+
+func NewEventRepository(db *sqlx.DB) data.EventRepository {
+	return &eventPG{db: db}
+}
+
+func (p *eventPG) Insert(ctx context.Context, event *data.EconomicEvent) error {
+	query := `
+		INSERT INTO economic_event (slug, kind, time, value)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	return p.db.QueryRowContext(ctx, query, event.Slug, event.Kind, event.Date, event.Value).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (p *eventPG) GetAfter(ctx context.Context, id int64, slugs []string, limit int) (*[]data.EconomicEvent, error) {
+	res := []data.EconomicEvent{}
+	query := `
+		SELECT id, slug, kind, time, value, created_at
+		FROM economic_event
+		WHERE id > $1 AND slug = ANY($2)
+		ORDER BY id
+		LIMIT $3`
+
+	err := p.db.SelectContext(ctx, &res, query, id, pq.Array(slugs), limit)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (p *eventPG) GetLatestID(ctx context.Context) (int64, error) {
+	var id int64
+	query := `SELECT COALESCE(MAX(id), 0) FROM economic_event`
+
+	err := p.db.GetContext(ctx, &id, query)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+type eventListenerPG struct {
+	dsn string
+}
+
+// NewEventListener listens for the events with its own connection, as LISTEN holds the connection for as long as it
+// listens
+func NewEventListener(dsn string) data.EventListener {
+	return &eventListenerPG{dsn: dsn}
+}
+
+// Listen handles the events notified on the channel until the context is done, the only error after listening starts
+// is the context's. The listener reconnects by itself, events notified while it is disconnected are missed but are
+// still replayed to stream clients which reconnect
+func (p *eventListenerPG) Listen(ctx context.Context, handle func(event data.EconomicEvent)) error {
+	listener := pq.NewListener(p.dsn, 10*time.Second, time.Minute, nil)
+	defer listener.Close()
+
+	err := listener.Listen(eventChannel)
+	if err != nil {
+		return errors.Wrapf(err, "error listening on %s", eventChannel)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-listener.Notify:
+			// A nil notification is sent after the connection is re-established
+			if n == nil {
+				continue
+			}
+			var event data.EconomicEvent
+			err := json.Unmarshal([]byte(n.Extra), &event)
+			if err != nil {
+				// A payload which can't be decoded is dropped, the events after it are still handled
+				utils.Logger(ctx).Error("error decoding notification", zap.Error(err),
+					zap.String("channel", eventChannel),
+					zap.String("payload", n.Extra),
+				)
+				continue
+			}
+			handle(event)
+		case <-time.After(90 * time.Second):
+			// Check the connection is still alive when nothing has been notified for a while, a dead connection is
+			// re-established by the listener
+			go listener.Ping()
+		}
+	}
+}
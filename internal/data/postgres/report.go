@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/mhamm84/pulse-api/internal/data"
 )
@@ -18,7 +20,7 @@ func (p *reportPG) GetAllReports(ctx context.Context) ([]*data.Report, error) {
 	reports := []*data.Report{}
 	query := `
 		SELECT
-		    slug, display_name, description, image, last_data_pull, initial_sync_delay_minutes, extras
+		    slug, display_name, description, unit, image, last_data_pull, initial_sync_delay_minutes, extras
 		FROM economic_report`
 
 	err := p.db.SelectContext(ctx, &reports, query)
@@ -43,13 +45,18 @@ func (p *reportPG) GetReportBySlug(ctx context.Context, slug string) (*data.Repo
 	report := data.Report{}
 	query := `
 		SELECT
-			slug, display_name, description, image, last_data_pull, initial_sync_delay_minutes, extras
+			slug, display_name, description, unit, image, last_data_pull, initial_sync_delay_minutes, extras
 		FROM economic_report
 		WHERE slug = $1`
 
 	err := p.db.GetContext(ctx, &report, query, slug)
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, data.ErrRecordNotFound
+		default:
+			return nil, err
+		}
 	}
 	return &report, nil
 }
@@ -58,7 +65,7 @@ func (p *reportPG) GetReports(ctx context.Context) (*[]data.Report, error) {
 	reports := []data.Report{}
 	query := `
 		SELECT
-			slug, display_name, description, image, last_data_pull, initial_sync_delay_minutes, extras
+			slug, display_name, description, unit, image, last_data_pull, initial_sync_delay_minutes, extras
 		FROM economic_report`
 
 	err := p.db.SelectContext(ctx, &reports, query)
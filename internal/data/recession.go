@@ -0,0 +1,39 @@
+package data
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// SahmReading is the Sahm rule for a month, the three month average unemployment rate less its low over the
+// prior twelve months, the rule is triggered at 0.5 percentage points
+type SahmReading struct {
+	Date              time.Time       `json:"date"`
+	Unemployment      decimal.Decimal `json:"unemployment"`
+	ThreeMonthAverage decimal.Decimal `json:"threeMonthAverage"`
+	PriorLow          decimal.Decimal `json:"priorLow"`
+	Value             decimal.Decimal `json:"value"`
+	Triggered         bool            `json:"triggered"`
+}
+
+// RecessionProbability is the probit model probability of a recession twelve months after Date, from the monthly
+// average 10y-3m spread in percentage points
+type RecessionProbability struct {
+	Date         time.Time       `json:"date"`
+	ForecastDate time.Time       `json:"forecastDate"`
+	SpreadPct    decimal.Decimal `json:"spreadPct"`
+	Probability  decimal.Decimal `json:"probability"`
+	Triggered    bool            `json:"triggered"`
+}
+
+// RecessionReading is the latest reading of each indicator, nil when there is not enough data to calculate it
+type RecessionReading struct {
+	Sahm        *SahmReading          `json:"sahm"`
+	Probability *RecessionProbability `json:"probability"`
+}
+
+type RecessionIndicators struct {
+	Current     RecessionReading       `json:"current"`
+	Sahm        []SahmReading          `json:"sahm"`
+	Probability []RecessionProbability `json:"probability"`
+}
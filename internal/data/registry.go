@@ -0,0 +1,74 @@
+package data
+
+import "sort"
+
+// seriesAliases keeps URL paths that predate the registry, where the path differs from the report slug
+var seriesAliases = map[string]string{
+	"nonfarm_payroll": "nonfarm_payrolls",
+}
+
+// Series is an economic series which can be served by the API
+type Series struct {
+	Slug       string     `json:"slug"`
+	ReportType ReportType `json:"-"`
+	Report     *Report    `json:"report,omitempty"`
+}
+
+// SeriesRegistry resolves slugs to the series the API can serve
+type SeriesRegistry struct {
+	series map[string]Series
+}
+
+// NewSeriesRegistry seeds the registry from the ReportType constants, as only they have a table the data sync fills.
+// The economic_report rows only add the metadata of the series, a row whose slug has no ReportType isn't served
+func NewSeriesRegistry(reports []*Report) *SeriesRegistry {
+	registry := &SeriesRegistry{series: make(map[string]Series, len(reportTypes))}
+	for _, report := range ReportTypes() {
+		slug := report.ToTable()
+		registry.series[slug] = Series{Slug: slug, ReportType: report}
+	}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		if s, ok := registry.series[report.Slug]; ok {
+			s.Report = report
+			registry.series[report.Slug] = s
+		}
+	}
+	return registry
+}
+
+// Lookup resolves a slug, or one of its aliases, to the series
+func (r *SeriesRegistry) Lookup(slug string) (Series, bool) {
+	if target, ok := seriesAliases[slug]; ok {
+		slug = target
+	}
+	s, ok := r.series[slug]
+	return s, ok
+}
+
+// All returns every series ordered by slug
+func (r *SeriesRegistry) All() []Series {
+	res := make([]Series, 0, len(r.series))
+	for _, s := range r.series {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Slug < res[j].Slug })
+	return res
+}
+
+// Paths returns every path a series can be requested by, the slugs and their aliases
+func (r *SeriesRegistry) Paths() []string {
+	paths := make([]string, 0, len(r.series)+len(seriesAliases))
+	for slug := range r.series {
+		paths = append(paths, slug)
+	}
+	for alias, slug := range seriesAliases {
+		if _, ok := r.series[slug]; ok {
+			paths = append(paths, alias)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
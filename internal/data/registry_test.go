@@ -0,0 +1,54 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReportTypeLookups(t *testing.T) {
+	for _, report := range ReportTypes() {
+		assert.NotEqual(t, "Unknown", report.String())
+		assert.Equal(t, report, ReportTypeFromSlug(report.ToTable()))
+	}
+	assert.Equal(t, "unknown", Unknown.ToTable())
+	assert.Equal(t, TreasuryYieldTenYear, ReportTypeTreasuryYieldMaturity("10y"))
+	assert.Equal(t, TreasuryMaturity("3m"), MaturityFromReportType(TreasuryYieldThreeMonth))
+	assert.Equal(t, Unknown, ReportTypeTreasuryYieldMaturity("1y"))
+
+	assert.Equal(t, []TreasuryMaturity{"3m", "2y", "5y", "7y", "10y", "30y"}, TreasuryMaturities)
+	for _, maturity := range TreasuryMaturities {
+		assert.NotEqual(t, Unknown, ReportTypeTreasuryYieldMaturity(string(maturity)))
+	}
+}
+
+func TestSeriesRegistry(t *testing.T) {
+	cpi := &Report{Slug: "cpi", DisplayName: "Consumer Price Index"}
+	registry := NewSeriesRegistry([]*Report{cpi, {Slug: "not_synced"}})
+
+	tests := []struct {
+		name   string
+		slug   string
+		want   ReportType
+		exists bool
+	}{
+		{name: "Slug", slug: "cpi", want: CPI, exists: true},
+		{name: "Treasury", slug: "treasury_yield_ten_year", want: TreasuryYieldTenYear, exists: true},
+		{name: "Alias", slug: "nonfarm_payroll", want: NonfarmPayroll, exists: true},
+		{name: "Report Without Type", slug: "not_synced", exists: false},
+		{name: "Unknown", slug: "gdp", exists: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := registry.Lookup(tt.slug)
+			assert.Equal(t, tt.exists, ok)
+			if tt.exists {
+				assert.Equal(t, tt.want, s.ReportType)
+			}
+		})
+	}
+
+	s, _ := registry.Lookup("cpi")
+	assert.Equal(t, cpi, s.Report)
+	assert.Len(t, registry.All(), len(ReportTypes()))
+	assert.Contains(t, registry.Paths(), "nonfarm_payroll")
+}
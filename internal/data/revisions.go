@@ -0,0 +1,39 @@
+package data
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Vintage is the value of an observation as it was known from RealtimeStart until RealtimeEnd, which is nil
+// while it is the current value
+type Vintage struct {
+	Value         decimal.Decimal  `db:"value" json:"value"`
+	RealtimeStart time.Time        `db:"realtime_start" json:"realtimeStart"`
+	RealtimeEnd   *time.Time       `db:"realtime_end" json:"realtimeEnd"`
+	Change        *decimal.Decimal `db:"-" json:"change,omitempty"`
+}
+
+// RevisionHistory is every vintage of the observation on a date, Revision is the current value less the first print
+type RevisionHistory struct {
+	Date       time.Time       `json:"date"`
+	FirstPrint decimal.Decimal `json:"firstPrint"`
+	Current    decimal.Decimal `json:"current"`
+	Revision   decimal.Decimal `json:"revision"`
+	Vintages   []Vintage       `json:"vintages"`
+}
+
+// RevisionSummary is an observation which has been revised since it was first printed
+type RevisionSummary struct {
+	Date        time.Time       `json:"date"`
+	FirstPrint  decimal.Decimal `json:"firstPrint"`
+	Current     decimal.Decimal `json:"current"`
+	Revision    decimal.Decimal `json:"revision"`
+	Revisions   int             `json:"revisions"`
+	LastRevised time.Time       `json:"lastRevised"`
+}
+
+type RevisionSummaryResult struct {
+	Data *[]RevisionSummary
+	Meta *Metadata
+}
@@ -0,0 +1,89 @@
+package data
+
+import (
+	"github.com/shopspring/decimal"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TreasuryMaturities are the maturities of the treasury yield report types, ordered from shortest to longest
+var TreasuryMaturities = treasuryMaturities()
+
+func treasuryMaturities() []TreasuryMaturity {
+	res := make([]TreasuryMaturity, 0)
+	for _, info := range reportTypes {
+		if info.maturity != "" {
+			res = append(res, info.maturity)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].months() < res[j].months() })
+	return res
+}
+
+// months is the length of the maturity, a number of months like 3m or years like 10y
+func (m TreasuryMaturity) months() int {
+	if len(m) < 2 {
+		return 0
+	}
+	n, err := strconv.Atoi(string(m[:len(m)-1]))
+	if err != nil {
+		return 0
+	}
+	if m[len(m)-1] == 'y' {
+		return n * 12
+	}
+	return n
+}
+
+type YieldCurvePoint struct {
+	Maturity TreasuryMaturity `json:"maturity"`
+	Date     time.Time        `json:"date"`
+	Value    decimal.Decimal  `json:"value"`
+}
+
+type YieldCurve struct {
+	Date   time.Time         `json:"date"`
+	Points []YieldCurvePoint `json:"points"`
+}
+
+// YieldCurveShift is the move in a maturity's yield between two curves
+type YieldCurveShift struct {
+	Maturity  TreasuryMaturity `json:"maturity"`
+	From      decimal.Decimal  `json:"from"`
+	To        decimal.Decimal  `json:"to"`
+	ChangeBps decimal.Decimal  `json:"changeBps"`
+}
+
+// TreasuryMaturityIndex is the position of the maturity in TreasuryMaturities, or -1 when it is unknown
+func TreasuryMaturityIndex(maturity TreasuryMaturity) int {
+	for i, m := range TreasuryMaturities {
+		if m == maturity {
+			return i
+		}
+	}
+	return -1
+}
+
+type YieldSpread struct {
+	Date      time.Time       `json:"date"`
+	SpreadBps decimal.Decimal `json:"spreadBps"`
+}
+
+// InversionEpisode is a run of consecutive observations where the short maturity yields more than the long
+type InversionEpisode struct {
+	Start        time.Time       `json:"start"`
+	End          time.Time       `json:"end"`
+	DurationDays int             `json:"durationDays"`
+	Observations int             `json:"observations"`
+	DeepestDate  time.Time       `json:"deepestDate"`
+	DeepestBps   decimal.Decimal `json:"deepestBps"`
+	Ongoing      bool            `json:"ongoing"`
+}
+
+type YieldSpreadResult struct {
+	Long       TreasuryMaturity   `json:"long"`
+	Short      TreasuryMaturity   `json:"short"`
+	Spreads    []YieldSpread      `json:"spreads"`
+	Inversions []InversionEpisode `json:"inversions"`
+}
@@ -0,0 +1,242 @@
+package openapi
+
+import (
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document, the schemas of the operations are generated from the Go values the API reads and
+// writes with SchemaOf
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type Operation struct {
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// OneOf is described as a value matching exactly one of the schemas of its values
+type OneOf []interface{}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	decimalType     = reflect.TypeOf(decimal.Decimal{})
+	nullDecimalType = reflect.TypeOf(decimal.NullDecimal{})
+	oneOfType       = reflect.TypeOf(OneOf{})
+)
+
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+		Components: Components{
+			Schemas:         make(map[string]*Schema),
+			SecuritySchemes: make(map[string]*SecurityScheme),
+		},
+	}
+}
+
+// Add adds the operation for the method of the path, the method is lower cased as the document requires
+func (d *Document) Add(method, path string, op *Operation) {
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(map[string]*Operation)
+	}
+	d.Paths[path][strings.ToLower(method)] = op
+}
+
+// Operation gets the operation for the method of the path, nil when it isn't in the document
+func (d *Document) Operation(method, path string) *Operation {
+	return d.Paths[path][strings.ToLower(method)]
+}
+
+// SchemaOf generates the schema of the value as encoding/json writes it. Named structs are added to the components
+// and referenced, a map holding values is described as an object with those properties, so an envelope like
+// map[string]interface{}{"data": []data.Economic{}} describes its properties
+func (d *Document) SchemaOf(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	if oneOf, ok := v.(OneOf); ok {
+		s := &Schema{}
+		for _, value := range oneOf {
+			s.OneOf = append(s.OneOf, d.SchemaOf(value))
+		}
+		return s
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String && value.Len() > 0 {
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema, value.Len())}
+		for _, key := range value.MapKeys() {
+			s.Properties[key.String()] = d.SchemaOf(value.MapIndex(key).Interface())
+		}
+		return s
+	}
+	return d.schemaOfType(value.Type())
+}
+
+func (d *Document) schemaOfType(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case decimalType:
+		return &Schema{Type: "string", Format: "decimal"}
+	case nullDecimalType:
+		return &Schema{Type: "string", Format: "decimal", Nullable: true}
+	case oneOfType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := d.schemaOfType(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		nullable := *s
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return d.schemaOfStruct(t)
+	}
+	// interfaces can hold any value
+	return &Schema{}
+}
+
+// schemaOfStruct adds a named struct to the components, the schema is added before its fields are generated so a
+// struct which refers to itself is referenced rather than recursing forever
+func (d *Document) schemaOfStruct(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		d.addFields(s, t)
+		return s
+	}
+
+	// An unexported type is named as if it were exported
+	name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := d.Components.Schemas[name]; ok {
+		return ref
+	}
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	d.Components.Schemas[name] = s
+	d.addFields(s, t)
+	return ref
+}
+
+// addFields adds the fields encoding/json writes, the fields of embedded structs are promoted as encoding/json does
+func (d *Document) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				d.addFields(s, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = d.schemaOfType(field.Type)
+	}
+}
@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type base struct {
+	ID int64 `json:"id"`
+}
+
+type observation struct {
+	base
+	Date     time.Time           `json:"date"`
+	Value    decimal.Decimal     `json:"value"`
+	Change   decimal.NullDecimal `json:"change"`
+	Score    *float64            `json:"score,omitempty"`
+	Hash     []byte              `json:"-"`
+	Revision *observation        `json:"revision"`
+	Tags     map[string]string   `json:"tags"`
+	Untagged bool
+	internal int
+}
+
+func TestDocument_SchemaOf(t *testing.T) {
+	d := New(Info{Title: "test", Version: "1"})
+
+	s := d.SchemaOf(map[string]interface{}{
+		"data": []observation{},
+		"meta": map[string]int{},
+	})
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/Observation"}}, s.Properties["data"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int32"}}, s.Properties["meta"])
+
+	assert.Len(t, d.Components.Schemas, 1)
+	obs := d.Components.Schemas["Observation"]
+	assert.Equal(t, map[string]*Schema{
+		"id":       {Type: "integer", Format: "int64"},
+		"date":     {Type: "string", Format: "date-time"},
+		"value":    {Type: "string", Format: "decimal"},
+		"change":   {Type: "string", Format: "decimal", Nullable: true},
+		"score":    {Type: "number", Format: "double", Nullable: true},
+		"revision": {Ref: "#/components/schemas/Observation"},
+		"tags":     {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		"Untagged": {Type: "boolean"},
+	}, obs.Properties)
+}
+
+func TestDocument_SchemaOf_Values(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  *Schema
+	}{
+		{name: "Nil", value: nil, want: &Schema{}},
+		{name: "String", value: "", want: &Schema{Type: "string"}},
+		{name: "EmptyMap", value: map[string]interface{}{}, want: &Schema{Type: "object", AdditionalProperties: &Schema{}}},
+		{name: "AnonymousStruct", value: struct {
+			Token string `json:"token"`
+		}{}, want: &Schema{Type: "object", Properties: map[string]*Schema{"token": {Type: "string"}}}},
+		{name: "OneOf", value: OneOf{"", []int{}}, want: &Schema{OneOf: []*Schema{
+			{Type: "string"},
+			{Type: "array", Items: &Schema{Type: "integer", Format: "int32"}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New(Info{Title: "test", Version: "1"})
+			assert.Equal(t, tt.want, d.SchemaOf(tt.value))
+			assert.Empty(t, d.Components.Schemas)
+		})
+	}
+}
+
+func TestDocument_Add(t *testing.T) {
+	d := New(Info{Title: "test", Version: "1"})
+	op := &Operation{Summary: "Get the reports"}
+
+	d.Add("GET", "/v1/economic/reports", op)
+
+	assert.Same(t, op, d.Operation("get", "/v1/economic/reports"))
+	assert.Same(t, op, d.Paths["/v1/economic/reports"]["get"])
+	assert.Nil(t, d.Operation("POST", "/v1/economic/reports"))
+	assert.Nil(t, d.Operation("GET", "/v1/economic/unknown"))
+}
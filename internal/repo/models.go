@@ -12,6 +12,8 @@ type Models struct {
 	UserRepository        data.UserRepository
 	PermissionsRepository data.PermissionsRepository
 	TokenRepository       data.TokenRepository
+	EventRepository       data.EventRepository
+	DashboardRepository   data.DashboardRepository
 }
 
 func NewModels(db *sqlx.DB) Models {
@@ -21,5 +23,7 @@ func NewModels(db *sqlx.DB) Models {
 		UserRepository:        postgres.NewUserRepository(db),
 		PermissionsRepository: postgres.NewPermissionsRepository(db),
 		TokenRepository:       postgres.NewTokenRepository(db),
+		EventRepository:       postgres.NewEventRepository(db),
+		DashboardRepository:   postgres.NewDashboardRepository(db),
 	}
 }
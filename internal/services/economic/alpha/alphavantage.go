@@ -8,11 +8,13 @@ import (
 	alphavantage "github.com/mhamm84/gofinance-alpha/alpha/data"
 	"github.com/mhamm84/pulse-api/internal/data"
 	"github.com/mhamm84/pulse-api/internal/jsonlog"
+	"github.com/mhamm84/pulse-api/internal/services/economic"
 	"github.com/mhamm84/pulse-api/internal/utils"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"golang.org/x/time/rate"
 	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -70,10 +72,31 @@ func (l *AlphaVantageEconomicData) UnmarshalJSON(j []byte) error {
 	return nil
 }
 
+// EventPublisher publishes the observations inserted or revised by the data sync to the stream clients
+type EventPublisher interface {
+	Publish(ctx context.Context, event *data.EconomicEvent) error
+}
+
+// CacheInvalidator drops the cached values of a series once the data sync has updated it
+type CacheInvalidator interface {
+	Invalidate(slug string)
+}
+
+// CacheInvalidators invalidates every cache of the series
+type CacheInvalidators []CacheInvalidator
+
+func (caches CacheInvalidators) Invalidate(slug string) {
+	for _, cache := range caches {
+		cache.Invalidate(slug)
+	}
+}
+
 type AlphaVantageEconomicService struct {
 	EconomicRepository data.EconomicRepository
 	ReportRepository   data.ReportRepository
 	Client             ClientInterface
+	Events             EventPublisher
+	Cache              CacheInvalidator
 	Logger             *jsonlog.Logger
 	Limiter            AlphaVantageLimiter
 }
@@ -83,9 +106,9 @@ type AlphaVantageLimiter struct {
 	DailyLimiter  *rate.Limiter
 }
 
-func (s AlphaVantageEconomicService) GetStats(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicStatsResult, errChan chan error, reportType data.ReportType, years int, timeBucketDays int, paging data.Paging) {
+func (s AlphaVantageEconomicService) GetStats(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicStatsResult, errChan chan error, reportType data.ReportType, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging) {
 	defer wg.Done()
-	data, err := s.EconomicRepository.GetStats(ctx, data.TableFromReportType(reportType), years, timeBucketDays, paging)
+	data, err := s.EconomicRepository.GetStats(ctx, data.TableFromReportType(reportType), dateRange, timeBucketDays, metrics, paging)
 	if err != nil {
 		errChan <- err
 	} else {
@@ -93,9 +116,9 @@ func (s AlphaVantageEconomicService) GetStats(ctx context.Context, wg *sync.Wait
 	}
 }
 
-func (s AlphaVantageEconomicService) GetIntervalWithPercentChange(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicWithChangeResult, errChan chan error, reportType data.ReportType, years int, paging data.Paging) {
+func (s AlphaVantageEconomicService) GetIntervalWithPercentChange(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicWithChangeResult, errChan chan error, reportType data.ReportType, filter data.SeriesFilter, paging data.Paging) {
 	defer wg.Done()
-	data, err := s.EconomicRepository.GetIntervalWithPercentChange(ctx, data.TableFromReportType(reportType), years, paging)
+	data, err := s.EconomicRepository.GetIntervalWithPercentChange(ctx, data.TableFromReportType(reportType), filter, paging)
 	if err != nil {
 		errChan <- err
 	} else {
@@ -103,6 +126,23 @@ func (s AlphaVantageEconomicService) GetIntervalWithPercentChange(ctx context.Co
 	}
 }
 
+// EachWithPercentChange calls fn with each observation of the filter as it is read from a single query, for callers
+// streaming a whole series rather than paging through it
+func (s AlphaVantageEconomicService) EachWithPercentChange(ctx context.Context, reportType data.ReportType, filter data.SeriesFilter, fn func(economic data.EconomicWithChange) error) error {
+	return s.EconomicRepository.EachWithPercentChange(ctx, data.TableFromReportType(reportType), filter, fn)
+}
+
+// EachPageWithPercentChange calls start with the metadata of the page and fn with each of its observations as they are
+// read, for callers streaming a page without holding it in memory
+func (s AlphaVantageEconomicService) EachPageWithPercentChange(ctx context.Context, reportType data.ReportType, filter data.SeriesFilter, paging data.Paging, start func(meta data.Metadata) error, fn func(economic data.EconomicWithChange) error) error {
+	return s.EconomicRepository.EachPageWithPercentChange(ctx, data.TableFromReportType(reportType), filter, paging, start, fn)
+}
+
+// EachStats calls start with the metadata of the page and fn with each of its buckets as they are read
+func (s AlphaVantageEconomicService) EachStats(ctx context.Context, reportType data.ReportType, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging, start func(meta data.Metadata) error, fn func(stats data.EconomicStats) error) error {
+	return s.EconomicRepository.EachStats(ctx, data.TableFromReportType(reportType), dateRange, timeBucketDays, metrics, paging, start, fn)
+}
+
 // GetAll Gets all the data for an economic table
 // if no data is found, a request is sent to the API to get the data to populate the DB
 func (s AlphaVantageEconomicService) GetAll(reportType data.ReportType) (*[]data.Economic, error) {
@@ -247,7 +287,10 @@ func start(taskParams DataSyncTaskParams) {
 			apiData := processApiCall(ctx, s, taskParams.reportType, opts, apiCall, tableName)
 
 			if apiData != nil {
-				s.insertNewData(ctx, tableName, apiData, data)
+				err = s.insertNewData(ctx, tableName, apiData, data)
+				if err != nil {
+					s.Logger.PrintError(err, nil)
+				}
 			}
 		}
 	})
@@ -278,28 +321,103 @@ func processApiCall(ctx context.Context, s *AlphaVantageEconomicService, reportT
 	return apiData
 }
 
+// insertNewData inserts the API observations for dates not in the DB yet, and revises those whose value has
+// changed since the last sync so the previous value is kept as an earlier vintage. Inserted and revised
+// observations are scored for anomalies against the observations before them and published to the stream clients
 func (s AlphaVantageEconomicService) insertNewData(ctx context.Context, tableName string, apiData *[]data.Economic, dbData *[]data.Economic) error {
 
-	dbMap := make(map[int64]*data.Economic)
+	dbMap := make(map[int64]data.Economic)
 	for _, data := range *dbData {
-		dbMap[data.Date.Unix()] = &data
+		dbMap[data.Date.Unix()] = data
 	}
 
-	for _, data := range *apiData {
-		if check := dbMap[data.Date.Unix()]; check == nil {
+	history := make([]data.Economic, len(*apiData))
+	copy(history, *apiData)
+	sort.Slice(history, func(i, j int) bool { return history[i].Date.After(history[j].Date) })
+	frequency := data.ReportTypeFromSlug(tableName).Frequency()
+
+	updated := false
+	defer func() {
+		if updated {
+			s.invalidateCache(tableName)
+		}
+	}()
+
+	for i, observation := range history {
+		var kind data.EconomicEventKind
+		check, ok := dbMap[observation.Date.Unix()]
+		switch {
+		case !ok:
 			s.Logger.PrintInfo(fmt.Sprintf("inserting new data point for %s", tableName), map[string]interface{}{
-				"date":  data.Date,
-				"value": data.Value,
+				"date":  observation.Date,
+				"value": observation.Value,
 			})
-			err := s.EconomicRepository.Insert(ctx, tableName, &data)
+			err := s.EconomicRepository.Insert(ctx, tableName, &observation)
 			if err != nil {
 				return err
 			}
+			kind = data.EventInserted
+		case !check.Value.Equal(observation.Value):
+			s.Logger.PrintInfo(fmt.Sprintf("revising data point for %s", tableName), map[string]interface{}{
+				"date":     observation.Date,
+				"value":    observation.Value,
+				"previous": check.Value,
+			})
+			err := s.EconomicRepository.Revise(ctx, tableName, &observation)
+			if err != nil {
+				return err
+			}
+			kind = data.EventRevised
+		default:
+			continue
 		}
+		updated = true
+		s.scoreAnomaly(ctx, tableName, observation, history[i+1:], frequency)
+		s.publishEvent(ctx, tableName, observation, kind)
 	}
 	return nil
 }
 
+// scoreAnomaly stores the anomaly score of a synced observation, a failure is only logged as the observation is stored
+func (s AlphaVantageEconomicService) scoreAnomaly(ctx context.Context, tableName string, observation data.Economic, history []data.Economic, frequency string) {
+	anomaly := economic.ScoreObservation(tableName, observation, history, frequency)
+	if anomaly == nil {
+		return
+	}
+	if anomaly.Outlier {
+		s.Logger.PrintWarning(fmt.Sprintf("anomalous data point for %s", tableName), map[string]interface{}{
+			"date":  observation.Date,
+			"value": observation.Value,
+			"score": anomaly.Score,
+		})
+	}
+	err := s.EconomicRepository.InsertAnomaly(ctx, anomaly)
+	if err != nil {
+		s.Logger.PrintWarning("error storing anomaly score", map[string]interface{}{
+			"report": tableName,
+			"date":   observation.Date,
+			"error":  err.Error(),
+		})
+	}
+}
+
+// publishEvent publishes a synced observation to the stream clients, a failure is only logged as the observation is stored
+func (s AlphaVantageEconomicService) publishEvent(ctx context.Context, tableName string, observation data.Economic, kind data.EconomicEventKind) {
+	err := s.Events.Publish(ctx, &data.EconomicEvent{
+		Slug:  tableName,
+		Kind:  kind,
+		Date:  observation.Date,
+		Value: observation.Value,
+	})
+	if err != nil {
+		s.Logger.PrintWarning("error publishing economic event", map[string]interface{}{
+			"report": tableName,
+			"date":   observation.Date,
+			"error":  err.Error(),
+		})
+	}
+}
+
 func (s AlphaVantageEconomicService) getDataFromApi(ctx context.Context, reportType alpha.ReportType, opts *alpha.Options, apiCall alphaEconomicCall) (*[]data.Economic, error) {
 	// Check the API limits
 	if !s.Limiter.DailyLimiter.Allow() {
@@ -352,5 +470,14 @@ func (s AlphaVantageEconomicService) insertMany(toSave *[]data.Economic, tableNa
 		})
 		return err
 	}
+	s.invalidateCache(tableName)
 	return nil
 }
+
+// invalidateCache drops the cached values of the series, the events published by insertNewData invalidate the caches
+// of the other replicas, but the initial insert of a series isn't published
+func (s AlphaVantageEconomicService) invalidateCache(tableName string) {
+	if s.Cache != nil {
+		s.Cache.Invalidate(tableName)
+	}
+}
@@ -1,9 +1,16 @@
 package alpha
 
 import (
+	"context"
 	"encoding/json"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/mhamm84/pulse-api/internal/jsonlog"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
 	"testing"
+	"time"
 )
 
 func TestCpiAlphaParse(t *testing.T) {
@@ -30,3 +37,61 @@ func TestCpiAlphaParse(t *testing.T) {
 	assert.Equal(t, "index 1982-1984=100", cpiAlphaResponse.Unit)
 	assert.Len(t, cpiAlphaResponse.Data, 3)
 }
+
+// mockEconomicRepository only implements the writes made by the data sync
+type mockEconomicRepository struct {
+	data.EconomicRepository
+	mock.Mock
+}
+
+func (m *mockEconomicRepository) Insert(ctx context.Context, table string, data *data.Economic) error {
+	return m.Called(ctx, table, *data).Error(0)
+}
+
+func (m *mockEconomicRepository) Revise(ctx context.Context, table string, data *data.Economic) error {
+	return m.Called(ctx, table, *data).Error(0)
+}
+
+type mockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, event *data.EconomicEvent) error {
+	return m.Called(ctx, *event).Error(0)
+}
+
+type mockCacheInvalidator struct {
+	mock.Mock
+}
+
+func (m *mockCacheInvalidator) Invalidate(slug string) {
+	m.Called(slug)
+}
+
+func TestInsertNewData(t *testing.T) {
+	ctx := context.Background()
+	observation := func(month time.Month, value float64) data.Economic {
+		return data.Economic{Date: time.Date(2022, month, 1, 0, 0, 0, 0, time.UTC), Value: decimal.NewFromFloat(value)}
+	}
+	dbData := []data.Economic{observation(4, 289.109), observation(3, 287.504)}
+	apiData := []data.Economic{observation(5, 292.296), observation(4, 289.2), observation(3, 287.504)}
+
+	mockRepo := new(mockEconomicRepository)
+	mockRepo.On("Insert", mock.Anything, "cpi", apiData[0]).Return(nil).Once()
+	mockRepo.On("Revise", mock.Anything, "cpi", apiData[1]).Return(nil).Once()
+
+	mockEvents := new(mockEventPublisher)
+	mockEvents.On("Publish", mock.Anything, data.EconomicEvent{Slug: "cpi", Kind: data.EventInserted, Date: apiData[0].Date, Value: apiData[0].Value}).Return(nil).Once()
+	mockEvents.On("Publish", mock.Anything, data.EconomicEvent{Slug: "cpi", Kind: data.EventRevised, Date: apiData[1].Date, Value: apiData[1].Value}).Return(nil).Once()
+
+	mockCache := new(mockCacheInvalidator)
+	mockCache.On("Invalidate", "cpi").Once()
+
+	s := AlphaVantageEconomicService{EconomicRepository: mockRepo, Events: mockEvents, Cache: mockCache, Logger: jsonlog.New(io.Discard, jsonlog.LevelOff)}
+	err := s.insertNewData(ctx, "cpi", &apiData, &dbData)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
@@ -0,0 +1,80 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+)
+
+type AnalyticsService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// Rolling gets the trailing window statistics for each observation of the report
+func (s AnalyticsService) Rolling(ctx context.Context, report data.ReportType, filter data.SeriesFilter, window data.RollingWindow, paging data.Paging) (*data.RollingResult, error) {
+	res, err := s.EconomicRepository.GetRolling(ctx, report.ToTable(), filter, window, paging)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting %s rolling stats", report.ToTable())
+	}
+	return res, nil
+}
+
+// Correlation gets the reports resampled to the filter frequency and correlates each pair of them on the
+// dates both have a value
+func (s AnalyticsService) Correlation(ctx context.Context, reports []data.ReportType, filter data.SeriesFilter, method data.CorrelationMethod) (*data.CorrelationMatrix, error) {
+	slugs := make([]string, 0, len(reports))
+	series := make(map[string][]data.Economic, len(reports))
+	for _, report := range reports {
+		slug := report.ToTable()
+		observations, err := s.EconomicRepository.GetRange(ctx, slug, filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting %s data to correlate", slug)
+		}
+		series[slug] = *observations
+		slugs = append(slugs, slug)
+	}
+
+	matrix := data.CorrelationMatrix{
+		Series:       slugs,
+		Method:       method,
+		Frequency:    filter.Frequency,
+		Coefficients: make([][]*float64, len(slugs)),
+		Observations: make([][]int, len(slugs)),
+	}
+	for i := range slugs {
+		matrix.Coefficients[i] = make([]*float64, len(slugs))
+		matrix.Observations[i] = make([]int, len(slugs))
+	}
+
+	for i := range slugs {
+		for j := i; j < len(slugs); j++ {
+			x, y := pairedValues(slugs[i], slugs[j], series)
+			matrix.Observations[i][j], matrix.Observations[j][i] = len(x), len(x)
+
+			var coefficient float64
+			var ok bool
+			switch method {
+			case data.CorrelationSpearman:
+				coefficient, ok = spearman(x, y)
+			default:
+				coefficient, ok = pearson(x, y)
+			}
+			if ok {
+				matrix.Coefficients[i][j], matrix.Coefficients[j][i] = &coefficient, &coefficient
+			}
+		}
+	}
+	return &matrix, nil
+}
+
+// pairedValues are the values of two series on the dates they both have an observation
+func pairedValues(a, b string, series map[string][]data.Economic) ([]float64, []float64) {
+	rows := alignSeries([]string{a, b}, series, data.AlignInner)
+	x := make([]float64, 0, len(rows))
+	y := make([]float64, 0, len(rows))
+	for _, row := range rows {
+		x = append(x, row.Values[a].InexactFloat64())
+		y = append(y, row.Values[b].InexactFloat64())
+	}
+	return x, y
+}
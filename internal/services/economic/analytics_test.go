@@ -0,0 +1,71 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsService_Rolling(t *testing.T) {
+	ctx := context.Background()
+	filter := data.SeriesFilter{DateRange: data.DateRange{From: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	window := data.RollingWindow{Size: 3, Functions: []data.RollingFunction{data.RollingMean, data.RollingZScore}}
+	paging := data.Paging{Page: 1, PageSize: 10}
+
+	mean, zscore := decimal.NewFromInt(101), decimal.NewFromFloat(1.5)
+	stats := []data.RollingStats{{Date: filter.DateRange.From, Value: decimal.NewFromInt(102), Mean: &mean, ZScore: &zscore}}
+	metadata := data.CalculateMetadata(1, paging.Page, paging.PageSize)
+	result := &data.RollingResult{Data: &stats, Meta: &metadata}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("GetRolling", mock.Anything, "cpi", filter, window, paging).Return(result, nil).Once()
+
+		s := AnalyticsService{EconomicRepository: mockRepo}
+		res, err := s.Rolling(ctx, data.CPI, filter, window, paging)
+		mockRepo.AssertExpectations(t)
+
+		assert.NoError(t, err)
+		assert.Equal(t, result, res)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("GetRolling", mock.Anything, "unemployment", filter, window, paging).Return(nil, errors.New("timeout")).Once()
+
+		s := AnalyticsService{EconomicRepository: mockRepo}
+		res, err := s.Rolling(ctx, data.Unemployment, filter, window, paging)
+		mockRepo.AssertExpectations(t)
+
+		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "timeout")
+	})
+}
+
+func TestAnalyticsService_Correlation(t *testing.T) {
+	ctx := context.Background()
+	filter := data.SeriesFilter{Frequency: data.FrequencyMonthly}
+	_, series := compareTestSeries()
+	cpi := series["cpi"]
+	unemployment := series["unemployment"]
+
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("GetRange", mock.Anything, "cpi", filter).Return(&cpi, nil).Once()
+	mockRepo.On("GetRange", mock.Anything, "unemployment", filter).Return(&unemployment, nil).Once()
+
+	s := AnalyticsService{EconomicRepository: mockRepo}
+	matrix, err := s.Correlation(ctx, []data.ReportType{data.CPI, data.Unemployment}, filter, data.CorrelationPearson)
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpi", "unemployment"}, matrix.Series)
+	assert.Equal(t, 3, matrix.Observations[0][0])
+	assert.Equal(t, 2, matrix.Observations[0][1])
+	assert.InDelta(t, 1.0, *matrix.Coefficients[0][0], 1e-9)
+	assert.Nil(t, matrix.Coefficients[0][1], "two overlapping observations is too few to correlate")
+}
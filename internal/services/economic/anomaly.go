@@ -0,0 +1,94 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"math"
+	"time"
+)
+
+const (
+	// anomalyWindow is the number of trailing changes an observation's change is scored against
+	anomalyWindow = 24
+	// minAnomalyHistory is the fewest trailing changes to score against
+	minAnomalyHistory = 6
+	// minSeasonalYears is the fewest changes in the same month of prior years for a seasonal score
+	minSeasonalYears = 3
+	// anomalyThreshold is the robust z-score an outlier is at least, as recommended by Iglewicz and Hoaglin
+	anomalyThreshold = 3.5
+	// maxAnomalyScore is the score of a change from changes without any spread, whose z-score is infinite
+	maxAnomalyScore = 10
+)
+
+type AnomalyService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// Anomalies gets the outliers of every report observed since the date
+func (s AnomalyService) Anomalies(ctx context.Context, since time.Time, paging data.Paging) (*data.AnomalyResult, error) {
+	res, err := s.EconomicRepository.GetAnomalies(ctx, since, paging)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting anomalies")
+	}
+	return res, nil
+}
+
+// ScoreObservation scores the observation's change from the previous observation, history is the observations
+// before it ordered by date descending. Monthly series also get a seasonal score against the changes in the same
+// month of prior years. Returns nil when there isn't enough history to score against
+func ScoreObservation(slug string, observation data.Economic, history []data.Economic, frequency string) *data.Anomaly {
+	if len(history) <= minAnomalyHistory {
+		return nil
+	}
+
+	change := observation.Value.Sub(history[0].Value).InexactFloat64()
+	changes := make([]float64, 0, anomalyWindow)
+	for i := 0; i+1 < len(history) && i < anomalyWindow; i++ {
+		changes = append(changes, history[i].Value.Sub(history[i+1].Value).InexactFloat64())
+	}
+	score := anomalyScore(change, changes)
+
+	anomaly := data.Anomaly{
+		Slug:   slug,
+		Date:   observation.Date,
+		Value:  observation.Value,
+		Change: decimal.NewFromFloat(change),
+		AnomalyScore: data.AnomalyScore{
+			Score:   decimal.NewFromFloat(score).Round(2),
+			Outlier: math.Abs(score) >= anomalyThreshold,
+		},
+	}
+
+	if frequency == "monthly" {
+		seasonal := make([]float64, 0)
+		for i := 0; i+1 < len(history); i++ {
+			if history[i].Date.Month() == observation.Date.Month() {
+				seasonal = append(seasonal, history[i].Value.Sub(history[i+1].Value).InexactFloat64())
+			}
+		}
+		if len(seasonal) >= minSeasonalYears {
+			seasonalScore := anomalyScore(change, seasonal)
+			anomaly.SeasonalScore = decimal.NewNullDecimal(decimal.NewFromFloat(seasonalScore).Round(2))
+			anomaly.Outlier = anomaly.Outlier || math.Abs(seasonalScore) >= anomalyThreshold
+		}
+	}
+	return &anomaly
+}
+
+// anomalyScore is the robust z-score of the change against the sample. When the sample has no spread, like a series
+// which hasn't changed, any other change is an outlier with the capped score and only the same change scores 0
+func anomalyScore(change float64, sample []float64) float64 {
+	if score, ok := robustZScore(change, sample); ok {
+		return score
+	}
+	m := median(sample)
+	switch {
+	case change > m:
+		return maxAnomalyScore
+	case change < m:
+		return -maxAnomalyScore
+	}
+	return 0
+}
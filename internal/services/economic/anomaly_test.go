@@ -0,0 +1,88 @@
+package economic
+
+import (
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// monthlySeries is ordered by date descending, the values are given oldest first
+func monthlySeries(start time.Time, values ...float64) []data.Economic {
+	series := make([]data.Economic, len(values))
+	for i, value := range values {
+		series[len(values)-1-i] = data.Economic{Date: start.AddDate(0, i, 0), Value: decimal.NewFromFloat(value)}
+	}
+	return series
+}
+
+func TestScoreObservation(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	values := make([]float64, 0, 40)
+	for i := 0; i < 40; i++ {
+		// Steady growth with a little noise and a jump every January
+		value := 100 + float64(i) + 0.1*float64((i*7)%5)
+		if i%12 == 0 {
+			value += 5
+		}
+		values = append(values, value)
+	}
+	history := monthlySeries(start, values...)
+	next := start.AddDate(0, 40, 0)
+
+	t.Run("Shock", func(t *testing.T) {
+		anomaly := ScoreObservation("cpi", data.Economic{Date: next, Value: history[0].Value.Add(decimal.NewFromInt(10))}, history, "monthly")
+
+		assert.NotNil(t, anomaly)
+		assert.Equal(t, "cpi", anomaly.Slug)
+		assert.True(t, anomaly.Outlier)
+		assert.True(t, anomaly.Score.GreaterThan(decimal.NewFromFloat(anomalyThreshold)))
+	})
+
+	t.Run("Usual", func(t *testing.T) {
+		anomaly := ScoreObservation("cpi", data.Economic{Date: next, Value: history[0].Value.Add(decimal.NewFromInt(1))}, history, "monthly")
+
+		assert.False(t, anomaly.Outlier)
+		assert.True(t, anomaly.SeasonalScore.Valid)
+	})
+
+	t.Run("Not Enough History", func(t *testing.T) {
+		assert.Nil(t, ScoreObservation("cpi", data.Economic{Date: next}, history[:3], "monthly"))
+	})
+
+	t.Run("Not Seasonal", func(t *testing.T) {
+		anomaly := ScoreObservation("treasury_yield_ten_year", data.Economic{Date: next, Value: history[0].Value}, history, "daily")
+
+		assert.False(t, anomaly.SeasonalScore.Valid)
+	})
+
+	t.Run("No Spread", func(t *testing.T) {
+		flat := make([]float64, 40)
+		for i := range flat {
+			flat[i] = 100
+		}
+		history := monthlySeries(start, flat...)
+
+		tests := []struct {
+			name    string
+			value   float64
+			score   float64
+			outlier bool
+		}{
+			{name: "Unchanged", value: 100, score: 0, outlier: false},
+			{name: "Rise", value: 100.1, score: maxAnomalyScore, outlier: true},
+			{name: "Fall", value: 99.9, score: -maxAnomalyScore, outlier: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				anomaly := ScoreObservation("fed_funds_rate", data.Economic{Date: next, Value: decimal.NewFromFloat(tt.value)}, history, "monthly")
+
+				assert.Equal(t, tt.outlier, anomaly.Outlier)
+				assert.True(t, decimal.NewFromFloat(tt.score).Equal(anomaly.Score), anomaly.Score.String())
+				assert.True(t, anomaly.SeasonalScore.Valid)
+				assert.True(t, decimal.NewFromFloat(tt.score).Equal(anomaly.SeasonalScore.Decimal))
+			})
+		}
+	})
+}
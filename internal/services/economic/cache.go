@@ -0,0 +1,182 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"sync"
+	"time"
+)
+
+// CacheStats are the hits and misses of the summary cache since the API started, Series counts the latest values of
+// the series and Summary the summary of the default dashboard. The entries are the number of each held now, an entry
+// is held until it's invalidated or replaced so an expired one is counted
+type CacheStats struct {
+	SeriesHits     int64 `json:"seriesHits"`
+	SeriesMisses   int64 `json:"seriesMisses"`
+	SeriesEntries  int   `json:"seriesEntries"`
+	SummaryHits    int64 `json:"summaryHits"`
+	SummaryMisses  int64 `json:"summaryMisses"`
+	SummaryEntries int   `json:"summaryEntries"`
+	Invalidations  int64 `json:"invalidations"`
+}
+
+type latestKey struct {
+	slug   string
+	change data.Change
+}
+
+type cachedLatest struct {
+	latest  data.EconomicWithChange
+	expires time.Time
+}
+
+// SummaryCache holds the latest value and change of the series, and the summary of the default dashboard built from
+// them. A series is invalidated when the data sync inserts or revises its observations, the TTL only bounds how long a
+// replica serves a value synced by another replica whose invalidation it missed.
+//
+// Each invalidation bumps a generation, a value fetched before an invalidation of its series isn't stored, so a
+// fetch racing the sync can't put the previous value back in the cache
+type SummaryCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	broker     *EventBroker
+	latest     map[latestKey]cachedLatest
+	generation uint64
+	// slugs are the generations the series were last invalidated at, cleared the generation of the last InvalidateAll
+	slugs   map[string]uint64
+	cleared uint64
+	summary *[]data.Summary
+	expires time.Time
+	stats   CacheStats
+}
+
+func NewSummaryCache(ttl time.Duration, broker *EventBroker) *SummaryCache {
+	return &SummaryCache{
+		ttl:    ttl,
+		now:    time.Now,
+		broker: broker,
+		latest: make(map[latestKey]cachedLatest),
+		slugs:  make(map[string]uint64),
+	}
+}
+
+// Latest gets the cached latest value of the series with the change, and the generation to store it with on a miss
+func (c *SummaryCache) Latest(slug string, change data.Change) (*data.EconomicWithChange, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.latest[latestKey{slug: slug, change: change}]
+	if !ok || c.now().After(entry.expires) {
+		c.stats.SeriesMisses++
+		return nil, c.generation, false
+	}
+	c.stats.SeriesHits++
+	latest := entry.latest
+	return &latest, 0, true
+}
+
+// SetLatest stores the latest value of the series, unless the series was invalidated since the generation was read
+func (c *SummaryCache) SetLatest(slug string, change data.Change, generation uint64, latest data.EconomicWithChange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.slugs[slug] > generation || c.cleared > generation {
+		return
+	}
+	c.latest[latestKey{slug: slug, change: change}] = cachedLatest{latest: latest, expires: c.now().Add(c.ttl)}
+}
+
+// Summary gets a copy of the cached summary of the default dashboard, and the generation to store it with on a miss
+func (c *SummaryCache) Summary() (*[]data.Summary, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.summary == nil || c.now().After(c.expires) {
+		c.stats.SummaryMisses++
+		return nil, c.generation, false
+	}
+	c.stats.SummaryHits++
+	summary := make([]data.Summary, len(*c.summary))
+	copy(summary, *c.summary)
+	return &summary, 0, true
+}
+
+// SetSummary stores the summary of the default dashboard, unless any series was invalidated since the generation was
+// read
+func (c *SummaryCache) SetSummary(generation uint64, summary []data.Summary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	stored := make([]data.Summary, len(summary))
+	copy(stored, summary)
+	c.summary = &stored
+	c.expires = c.now().Add(c.ttl)
+}
+
+// Invalidate drops the latest values of the series, and the summary
+func (c *SummaryCache) Invalidate(slug string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.slugs[slug] = c.generation
+	for key := range c.latest {
+		if key.slug == slug {
+			delete(c.latest, key)
+		}
+	}
+	c.summary = nil
+	c.stats.Invalidations++
+}
+
+// InvalidateAll drops every cached value
+func (c *SummaryCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.cleared = c.generation
+	c.latest = make(map[latestKey]cachedLatest)
+	c.summary = nil
+	c.stats.Invalidations++
+}
+
+func (c *SummaryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.SeriesEntries = len(c.latest)
+	if c.summary != nil {
+		stats.SummaryEntries = 1
+	}
+	return stats
+}
+
+// Listen invalidates the series of the events published by the sync of every replica until the context is done
+func (c *SummaryCache) Listen(ctx context.Context) {
+	invalidateOnEvents(ctx, c.broker, c.Invalidate, c.InvalidateAll)
+}
+
+// cachedLatestRepository gets the latest values of the series through the cache
+type cachedLatestRepository struct {
+	data.EconomicRepository
+	cache *SummaryCache
+}
+
+func (r cachedLatestRepository) LatestWithPercentChange(ctx context.Context, table string, change data.Change) (*data.EconomicWithChange, error) {
+	latest, generation, ok := r.cache.Latest(table, change)
+	if ok {
+		return latest, nil
+	}
+	latest, err := r.EconomicRepository.LatestWithPercentChange(ctx, table, change)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.SetLatest(table, change, generation, *latest)
+	return latest, nil
+}
@@ -0,0 +1,160 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestSummaryCache_Latest(t *testing.T) {
+	cache := NewSummaryCache(time.Hour, NewEventBroker())
+	latest := data.EconomicWithChange{Value: decimal.NewFromFloat(296.2)}
+
+	_, generation, ok := cache.Latest("cpi", data.DefaultChange)
+	assert.False(t, ok)
+	cache.SetLatest("cpi", data.DefaultChange, generation, latest)
+
+	res, _, ok := cache.Latest("cpi", data.DefaultChange)
+	assert.True(t, ok)
+	assert.Equal(t, latest, *res)
+	// the yoy change is cached separately
+	_, _, ok = cache.Latest("cpi", data.Change{Type: data.ChangeYearOverYear, Lag: 1})
+	assert.False(t, ok)
+
+	cache.Invalidate("cpi")
+	_, _, ok = cache.Latest("cpi", data.DefaultChange)
+	assert.False(t, ok)
+
+	assert.Equal(t, CacheStats{SeriesHits: 1, SeriesMisses: 3, Invalidations: 1}, cache.Stats())
+}
+
+func TestSummaryCache_SetAfterInvalidate(t *testing.T) {
+	cache := NewSummaryCache(time.Hour, NewEventBroker())
+	latest := data.EconomicWithChange{Value: decimal.NewFromFloat(296.2)}
+
+	// a value fetched before the sync updated the series isn't stored
+	_, generation, _ := cache.Latest("cpi", data.DefaultChange)
+	cache.Invalidate("cpi")
+	cache.SetLatest("cpi", data.DefaultChange, generation, latest)
+	_, _, ok := cache.Latest("cpi", data.DefaultChange)
+	assert.False(t, ok)
+
+	// an update of another series doesn't stop it being stored
+	_, generation, _ = cache.Latest("cpi", data.DefaultChange)
+	cache.Invalidate("unemployment")
+	cache.SetLatest("cpi", data.DefaultChange, generation, latest)
+	_, _, ok = cache.Latest("cpi", data.DefaultChange)
+	assert.True(t, ok)
+
+	_, generation, _ = cache.Latest("retail_sales", data.DefaultChange)
+	cache.InvalidateAll()
+	cache.SetLatest("retail_sales", data.DefaultChange, generation, latest)
+	_, _, ok = cache.Latest("retail_sales", data.DefaultChange)
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Stats().SeriesEntries)
+}
+
+func TestSummaryCache_Summary(t *testing.T) {
+	now := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewSummaryCache(time.Hour, NewEventBroker())
+	cache.now = func() time.Time { return now }
+	summary := []data.Summary{{Name: "CPI", Slug: "cpi"}}
+
+	_, generation, ok := cache.Summary()
+	assert.False(t, ok)
+	cache.SetSummary(generation, summary)
+
+	res, _, ok := cache.Summary()
+	assert.True(t, ok)
+	assert.Equal(t, summary, *res)
+	assert.Equal(t, 1, cache.Stats().SummaryEntries)
+
+	// the summary is dropped when any of the series is updated
+	cache.Invalidate("treasury_yield_ten_year")
+	assert.Equal(t, 0, cache.Stats().SummaryEntries)
+	_, generation, ok = cache.Summary()
+	assert.False(t, ok)
+	cache.SetSummary(generation, summary)
+
+	now = now.Add(time.Hour + time.Second)
+	_, _, ok = cache.Summary()
+	assert.False(t, ok)
+}
+
+func TestSummaryCache_Listen(t *testing.T) {
+	broker := NewEventBroker()
+	cache := NewSummaryCache(time.Hour, broker)
+	_, generation, _ := cache.Latest("cpi", data.DefaultChange)
+	cache.SetLatest("cpi", data.DefaultChange, generation, data.EconomicWithChange{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		cache.Listen(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		broker.Publish(data.EconomicEvent{ID: time.Now().UnixNano(), Slug: "cpi"})
+		return cache.Stats().SeriesEntries == 0
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestDashboardService_GetDashboardSummary_Cached(t *testing.T) {
+	items := DefaultDashboard().Items
+
+	t.Run("Cached", func(t *testing.T) {
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("LatestWithPercentChange", mock.Anything, mock.Anything, mock.Anything).Return(&data.EconomicWithChange{}, nil).Times(len(items))
+		s := DashboardService{EconomicRepository: mockRepo, Cache: NewSummaryCache(time.Hour, NewEventBroker())}
+
+		first, err := s.GetDashboardSummary()
+		assert.NoError(t, err)
+		second, err := s.GetDashboardSummary()
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+		assert.Equal(t, first, second)
+		assert.Equal(t, CacheStats{SeriesMisses: int64(len(items)), SeriesEntries: len(items), SummaryHits: 1, SummaryMisses: 1, SummaryEntries: 1}, s.CacheStats())
+	})
+
+	t.Run("Invalidated", func(t *testing.T) {
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("LatestWithPercentChange", mock.Anything, mock.Anything, mock.Anything).Return(&data.EconomicWithChange{}, nil).Times(len(items))
+		mockRepo.On("LatestWithPercentChange", mock.Anything, "cpi", mock.Anything).Return(&data.EconomicWithChange{}, nil).Once()
+		s := DashboardService{EconomicRepository: mockRepo, Cache: NewSummaryCache(time.Hour, NewEventBroker())}
+
+		_, err := s.GetDashboardSummary()
+		assert.NoError(t, err)
+		s.Cache.Invalidate("cpi")
+		// only the updated series is fetched again
+		_, err = s.GetDashboardSummary()
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("LatestWithPercentChange", mock.Anything, "cpi", mock.Anything).Return(nil, errors.New("timeout")).Twice()
+		mockRepo.On("LatestWithPercentChange", mock.Anything, mock.Anything, mock.Anything).Return(&data.EconomicWithChange{}, nil).Times(len(items) - 1)
+		s := DashboardService{EconomicRepository: mockRepo, Cache: NewSummaryCache(time.Hour, NewEventBroker())}
+
+		first, err := s.GetDashboardSummary()
+		assert.NoError(t, err)
+		assert.Len(t, *first, len(items)-1)
+		// the summary missing cpi isn't cached, the other series are
+		_, err = s.GetDashboardSummary()
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+}
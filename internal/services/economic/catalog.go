@@ -0,0 +1,197 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+type CatalogService struct {
+	EconomicRepository data.EconomicRepository
+	ReportRepository   data.ReportRepository
+	// Cache is optional, the coverage of every series is queried on every call without it
+	Cache *CoverageCache
+}
+
+// Reports gets the catalog entry of every report with data served by the API, ordered by slug
+func (s CatalogService) Reports(ctx context.Context) (*[]data.CatalogEntry, error) {
+	reports, err := s.ReportRepository.GetReports(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting reports for the catalog")
+	}
+
+	entries := make([]data.CatalogEntry, 0, len(*reports))
+	for _, report := range *reports {
+		reportType := data.ReportTypeFromSlug(report.Slug)
+		if reportType == data.Unknown {
+			continue
+		}
+		entry, err := s.catalogEntry(ctx, report, reportType)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Slug < entries[j].Slug })
+	return &entries, nil
+}
+
+// Report gets the catalog entry of the report, returning data.ErrRecordNotFound when it isn't served by the API
+func (s CatalogService) Report(ctx context.Context, slug string) (*data.CatalogEntry, error) {
+	reportType := data.ReportTypeFromSlug(slug)
+	if reportType == data.Unknown {
+		return nil, data.ErrRecordNotFound
+	}
+	report, err := s.ReportRepository.GetReportBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.catalogEntry(ctx, *report, reportType)
+}
+
+func (s CatalogService) catalogEntry(ctx context.Context, report data.Report, reportType data.ReportType) (*data.CatalogEntry, error) {
+	economicRepo := s.EconomicRepository
+	if s.Cache != nil {
+		economicRepo = cachedCoverageRepository{EconomicRepository: s.EconomicRepository, cache: s.Cache}
+	}
+	coverage, err := economicRepo.GetCoverage(ctx, reportType.ToTable())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting %s coverage for the catalog", report.Slug)
+	}
+	entry := data.NewCatalogEntry(report, reportType, *coverage)
+	return &entry, nil
+}
+
+// CacheStats are the hits and misses of the coverage cache, zero without one
+func (s CatalogService) CacheStats() CoverageCacheStats {
+	if s.Cache == nil {
+		return CoverageCacheStats{}
+	}
+	return s.Cache.Stats()
+}
+
+// Listen invalidates the cache as the data sync of every replica updates the series, until the context is done
+func (s CatalogService) Listen(ctx context.Context) {
+	if s.Cache != nil {
+		invalidateOnEvents(ctx, s.Cache.broker, s.Cache.Invalidate, s.Cache.InvalidateAll)
+	}
+}
+
+// CoverageCacheStats are the hits and misses of the coverage cache since the API started, Entries is the number of
+// series with a cached coverage
+type CoverageCacheStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Invalidations int64 `json:"invalidations"`
+	Entries       int   `json:"entries"`
+}
+
+type cachedCoverage struct {
+	coverage data.Coverage
+	expires  time.Time
+}
+
+// CoverageCache holds the coverage of the series the catalog is built from, invalidated as the data sync updates a
+// series. A coverage fetched before an invalidation of its series isn't stored, as in the SummaryCache
+type CoverageCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	broker     *EventBroker
+	coverage   map[string]cachedCoverage
+	generation uint64
+	// slugs are the generations the series were last invalidated at, cleared the generation of the last InvalidateAll
+	slugs   map[string]uint64
+	cleared uint64
+	stats   CoverageCacheStats
+}
+
+func NewCoverageCache(ttl time.Duration, broker *EventBroker) *CoverageCache {
+	return &CoverageCache{
+		ttl:      ttl,
+		now:      time.Now,
+		broker:   broker,
+		coverage: make(map[string]cachedCoverage),
+		slugs:    make(map[string]uint64),
+	}
+}
+
+// Coverage gets the cached coverage of the series, and the generation to store it with on a miss
+func (c *CoverageCache) Coverage(slug string) (*data.Coverage, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.coverage[slug]
+	if !ok || c.now().After(entry.expires) {
+		c.stats.Misses++
+		return nil, c.generation, false
+	}
+	c.stats.Hits++
+	coverage := entry.coverage
+	return &coverage, 0, true
+}
+
+// SetCoverage stores the coverage of the series, unless the series was invalidated since the generation was read
+func (c *CoverageCache) SetCoverage(slug string, generation uint64, coverage data.Coverage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.slugs[slug] > generation || c.cleared > generation {
+		return
+	}
+	c.coverage[slug] = cachedCoverage{coverage: coverage, expires: c.now().Add(c.ttl)}
+}
+
+// Invalidate drops the coverage of the series
+func (c *CoverageCache) Invalidate(slug string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.slugs[slug] = c.generation
+	delete(c.coverage, slug)
+	c.stats.Invalidations++
+}
+
+// InvalidateAll drops the coverage of every series
+func (c *CoverageCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.cleared = c.generation
+	c.coverage = make(map[string]cachedCoverage)
+	c.stats.Invalidations++
+}
+
+func (c *CoverageCache) Stats() CoverageCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.coverage)
+	return stats
+}
+
+// cachedCoverageRepository gets the coverage of the series through the cache
+type cachedCoverageRepository struct {
+	data.EconomicRepository
+	cache *CoverageCache
+}
+
+func (r cachedCoverageRepository) GetCoverage(ctx context.Context, table string) (*data.Coverage, error) {
+	coverage, generation, ok := r.cache.Coverage(table)
+	if ok {
+		return coverage, nil
+	}
+	coverage, err := r.EconomicRepository.GetCoverage(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.SetCoverage(table, generation, *coverage)
+	return coverage, nil
+}
@@ -0,0 +1,117 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) GetAllReports(ctx context.Context) ([]*data.Report, error) {
+	return nil, nil
+}
+func (m *MockReportRepository) UpdateReportLastPullDate(ctx context.Context, slug string) error {
+	return nil
+}
+func (m *MockReportRepository) GetReportBySlug(ctx context.Context, slug string) (*data.Report, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.Report), args.Error(1)
+}
+func (m *MockReportRepository) GetReports(ctx context.Context) (*[]data.Report, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]data.Report), args.Error(1)
+}
+
+func TestCatalogService_Reports(t *testing.T) {
+	ctx := context.Background()
+	from, to := day(1), day(3)
+	reports := []data.Report{
+		{Slug: "unemployment", DisplayName: "Unemployment Rate", Unit: "percent"},
+		{Slug: "not_synced"},
+		{Slug: "cpi", DisplayName: "CPI", Unit: "index 1982-1984=100"},
+	}
+
+	mockReports := new(MockReportRepository)
+	mockReports.On("GetReports", mock.Anything).Return(&reports, nil).Once()
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("GetCoverage", mock.Anything, "cpi").Return(&data.Coverage{From: &from, To: &to, Observations: 3}, nil).Once()
+	mockRepo.On("GetCoverage", mock.Anything, "unemployment").Return(&data.Coverage{}, nil).Once()
+
+	s := CatalogService{EconomicRepository: mockRepo, ReportRepository: mockReports}
+	entries, err := s.Reports(ctx)
+	mockReports.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Len(t, *entries, 2)
+	cpi := (*entries)[0]
+	assert.Equal(t, "cpi", cpi.Slug)
+	assert.Equal(t, "index 1982-1984=100", cpi.Unit)
+	assert.Equal(t, "monthly", cpi.Frequency)
+	assert.Equal(t, 3, cpi.Coverage.Observations)
+	assert.Nil(t, (*entries)[1].Coverage.From)
+}
+
+func TestCatalogService_Report(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := CatalogService{}.Report(ctx, "not_synced")
+		assert.ErrorIs(t, err, data.ErrRecordNotFound)
+	})
+
+	t.Run("treasury", func(t *testing.T) {
+		mockReports := new(MockReportRepository)
+		mockReports.On("GetReportBySlug", mock.Anything, "treasury_yield_ten_year").Return(&data.Report{Slug: "treasury_yield_ten_year"}, nil).Once()
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("GetCoverage", mock.Anything, "treasury_yield_ten_year").Return(&data.Coverage{Observations: 10}, nil).Once()
+
+		entry, err := CatalogService{EconomicRepository: mockRepo, ReportRepository: mockReports}.Report(ctx, "treasury_yield_ten_year")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "daily", entry.Frequency)
+		assert.Equal(t, 10, entry.Coverage.Observations)
+	})
+}
+
+func TestCatalogService_Reports_Cached(t *testing.T) {
+	ctx := context.Background()
+	reports := []data.Report{{Slug: "cpi"}, {Slug: "unemployment"}}
+
+	mockReports := new(MockReportRepository)
+	mockReports.On("GetReports", mock.Anything).Return(&reports, nil).Times(3)
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("GetCoverage", mock.Anything, "cpi").Return(&data.Coverage{Observations: 3}, nil).Once()
+	mockRepo.On("GetCoverage", mock.Anything, "unemployment").Return(&data.Coverage{Observations: 5}, nil).Once()
+	s := CatalogService{EconomicRepository: mockRepo, ReportRepository: mockReports, Cache: NewCoverageCache(time.Hour, NewEventBroker())}
+
+	first, err := s.Reports(ctx)
+	assert.NoError(t, err)
+	second, err := s.Reports(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	// only the updated series is queried again
+	mockRepo.On("GetCoverage", mock.Anything, "cpi").Return(&data.Coverage{Observations: 4}, nil).Once()
+	s.Cache.Invalidate("cpi")
+	third, err := s.Reports(ctx)
+	assert.NoError(t, err)
+
+	mockReports.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	assert.Equal(t, 4, (*third)[0].Coverage.Observations)
+	assert.Equal(t, 5, (*third)[1].Coverage.Observations)
+	assert.Equal(t, CoverageCacheStats{Hits: 3, Misses: 3, Invalidations: 1, Entries: 2}, s.Cache.Stats())
+}
@@ -0,0 +1,82 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"sort"
+	"time"
+)
+
+type CompareService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// Compare gets each report over the date range and aligns them into one row per date with a value per report slug
+func (s CompareService) Compare(ctx context.Context, reports []data.ReportType, filter data.SeriesFilter, alignment data.Alignment) (*[]data.ComparisonRow, error) {
+	series := make(map[string][]data.Economic, len(reports))
+	slugs := make([]string, 0, len(reports))
+
+	for _, report := range reports {
+		slug := report.ToTable()
+		observations, err := s.EconomicRepository.GetRange(ctx, slug, filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting %s data to compare", slug)
+		}
+		series[slug] = *observations
+		slugs = append(slugs, slug)
+	}
+
+	rows := alignSeries(slugs, series, alignment)
+	return &rows, nil
+}
+
+// alignSeries joins the series on date, returning rows ordered by date descending
+func alignSeries(slugs []string, series map[string][]data.Economic, alignment data.Alignment) []data.ComparisonRow {
+	byDate := map[time.Time]map[string]*decimal.Decimal{}
+	for _, slug := range slugs {
+		for _, observation := range series[slug] {
+			date := observation.Date.UTC()
+			if _, ok := byDate[date]; !ok {
+				byDate[date] = make(map[string]*decimal.Decimal, len(slugs))
+			}
+			value := observation.Value
+			byDate[date][slug] = &value
+		}
+	}
+
+	dates := make([]time.Time, 0, len(byDate))
+	for date := range byDate {
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	rows := make([]data.ComparisonRow, 0, len(dates))
+	last := make(map[string]*decimal.Decimal, len(slugs))
+	for _, date := range dates {
+		values := make(map[string]*decimal.Decimal, len(slugs))
+		complete := true
+		for _, slug := range slugs {
+			value := byDate[date][slug]
+			if value != nil {
+				last[slug] = value
+			} else if alignment == data.AlignForwardFill {
+				value = last[slug]
+			}
+			if value == nil {
+				complete = false
+			}
+			values[slug] = value
+		}
+		if alignment == data.AlignInner && !complete {
+			continue
+		}
+		rows = append(rows, data.ComparisonRow{Date: date, Values: values})
+	}
+
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	return rows
+}
@@ -0,0 +1,73 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func day(d int) time.Time {
+	return time.Date(2022, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func observation(d int, value float64) data.Economic {
+	return data.Economic{Date: day(d), Value: decimal.NewFromFloat(value)}
+}
+
+func compareTestSeries() ([]string, map[string][]data.Economic) {
+	return []string{"cpi", "unemployment"}, map[string][]data.Economic{
+		"cpi":          {observation(3, 103), observation(2, 102), observation(1, 101)},
+		"unemployment": {observation(3, 3.5), observation(1, 3.6)},
+	}
+}
+
+func TestAlignSeries(t *testing.T) {
+	slugs, series := compareTestSeries()
+
+	t.Run("inner", func(t *testing.T) {
+		rows := alignSeries(slugs, series, data.AlignInner)
+
+		assert.Len(t, rows, 2)
+		assert.Equal(t, day(3), rows[0].Date)
+		assert.Equal(t, day(1), rows[1].Date)
+	})
+
+	t.Run("outer", func(t *testing.T) {
+		rows := alignSeries(slugs, series, data.AlignOuter)
+
+		assert.Len(t, rows, 3)
+		assert.Equal(t, day(2), rows[1].Date)
+		assert.Equal(t, "102", rows[1].Values["cpi"].String())
+		assert.Nil(t, rows[1].Values["unemployment"])
+	})
+
+	t.Run("ffill", func(t *testing.T) {
+		rows := alignSeries(slugs, series, data.AlignForwardFill)
+
+		assert.Len(t, rows, 3)
+		assert.Equal(t, "3.6", rows[1].Values["unemployment"].String())
+	})
+}
+
+func TestCompareService_Compare(t *testing.T) {
+	ctx := context.Background()
+	filter := data.SeriesFilter{DateRange: data.DateRange{From: day(1), To: day(3)}}
+	_, series := compareTestSeries()
+	cpi := series["cpi"]
+	unemployment := series["unemployment"]
+
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("GetRange", mock.Anything, "cpi", filter).Return(&cpi, nil).Once()
+	mockRepo.On("GetRange", mock.Anything, "unemployment", filter).Return(&unemployment, nil).Once()
+
+	s := CompareService{EconomicRepository: mockRepo}
+	rows, err := s.Compare(ctx, []data.ReportType{data.CPI, data.Unemployment}, filter, data.AlignInner)
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Len(t, *rows, 2)
+}
@@ -6,59 +6,171 @@ import (
 	"github.com/mhamm84/pulse-api/internal/jsonlog"
 	"github.com/mhamm84/pulse-api/internal/utils"
 	"go.uber.org/zap"
+	"sync"
 	"time"
 )
 
 const (
 	dashboardTimeout = 10
+	// summaryWorkers is the most series of a dashboard fetched at once
+	summaryWorkers = 4
 )
 
 type DashboardService struct {
-	EconomicRepository data.EconomicRepository
-	Logger             *jsonlog.Logger
+	EconomicRepository  data.EconomicRepository
+	DashboardRepository data.DashboardRepository
+	// Cache is optional, the summaries are fetched on every call without it
+	Cache  *SummaryCache
+	Logger *jsonlog.Logger
+}
+
+// DefaultDashboard is the dashboard of a user who hasn't created one of their own, and the summary of the public
+// economic dashboard
+func DefaultDashboard() data.Dashboard {
+	item := func(report data.ReportType, name string) data.DashboardItem {
+		return data.DashboardItem{Series: report.ToTable(), Change: data.ChangePeriodOverPeriod, Name: name}
+	}
+	return data.Dashboard{
+		Name: "Default",
+		Items: data.DashboardItems{
+			item(data.CPI, "CPI"),
+			item(data.ConsumerSentiment, "Consumer Sentiment"),
+			item(data.RetailSales, "Retail Sales"),
+			item(data.TreasuryYieldThreeMonth, "3M Treasury Yield"),
+			item(data.TreasuryYieldTwoYear, "2Y Treasury Yield"),
+			item(data.TreasuryYieldFiveYear, "5Y Treasury Yield"),
+			item(data.TreasuryYieldSevenYear, "7Y Treasury Yield"),
+			item(data.TreasuryYieldTenYear, "10Y Treasury Yield"),
+			item(data.TreasuryYieldThirtyYear, "30Y Treasury Yield"),
+		},
+	}
 }
 
 func (s DashboardService) GetDashboardSummary() (*[]data.Summary, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dashboardTimeout*time.Second)
 	defer cancel()
 
-	dashData := make([]data.Summary, 0, 10)
+	var generation uint64
+	if s.Cache != nil {
+		summaries, gen, ok := s.Cache.Summary()
+		if ok {
+			return summaries, nil
+		}
+		generation = gen
+	}
+
+	items := DefaultDashboard().Items
+	summaries, err := s.Summaries(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	// A summary missing a series isn't cached, so the series is fetched again on the next call
+	if s.Cache != nil && len(*summaries) == len(items) {
+		s.Cache.SetSummary(generation, *summaries)
+	}
+	return summaries, nil
+}
+
+// Summaries gets the latest value and change of the series of each item, in the order of the items. The series are
+// fetched concurrently, through the cache when there is one. A series which can't be summarised is left out rather
+// than failing the dashboard
+func (s DashboardService) Summaries(ctx context.Context, items data.DashboardItems) (*[]data.Summary, error) {
+	var economyRepo data.EconomicRepository = s.EconomicRepository
+	if s.Cache != nil {
+		economyRepo = cachedLatestRepository{EconomicRepository: s.EconomicRepository, cache: s.Cache}
+	}
+
+	results := make([]*data.Summary, len(items))
+	workers := make(chan struct{}, summaryWorkers)
+	wg := new(sync.WaitGroup)
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item data.DashboardItem) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			results[i] = itemSummary(ctx, economyRepo, item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	summaries := make([]data.Summary, 0, len(items))
+	for _, summary := range results {
+		if summary != nil {
+			summaries = append(summaries, *summary)
+		}
+	}
+	return &summaries, nil
+}
 
-	if cpiSummary := createDashSummary(ctx, s.EconomicRepository, data.CPI.ToTable(), "CPI", nil); cpiSummary != nil {
-		dashData = append(dashData, *cpiSummary)
+// CacheStats are the hits and misses of the cache, zero without one
+func (s DashboardService) CacheStats() CacheStats {
+	if s.Cache == nil {
+		return CacheStats{}
 	}
-	if consumerSummary := createDashSummary(ctx, s.EconomicRepository, data.ConsumerSentiment.ToTable(), "Consumer Sentiment", nil); consumerSummary != nil {
-		dashData = append(dashData, *consumerSummary)
+	return s.Cache.Stats()
+}
+
+// Listen invalidates the cache as the data sync of every replica updates the series, until the context is done
+func (s DashboardService) Listen(ctx context.Context) {
+	if s.Cache != nil {
+		s.Cache.Listen(ctx)
 	}
+}
 
-	if retailSalesSummary := createDashSummary(ctx, s.EconomicRepository, data.RetailSales.ToTable(), "Retail Sales", nil); retailSalesSummary != nil {
-		dashData = append(dashData, *retailSalesSummary)
+// Dashboards gets the dashboards of the user, the default dashboard when they haven't created any
+func (s DashboardService) Dashboards(ctx context.Context, userID int64) (*[]data.Dashboard, error) {
+	dashboards, err := s.DashboardRepository.GetAllForUser(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
+	if len(*dashboards) == 0 {
+		*dashboards = append(*dashboards, DefaultDashboard())
+	}
+	return dashboards, nil
+}
 
-	treasurySummaries := []data.Summary{}
-	add(ctx, s.EconomicRepository, &treasurySummaries, data.TreasuryYieldThreeMonth.ToTable(), "3M Treasury Yield", addTreasuryExtras(data.TreasuryYieldThreeMonth))
-	add(ctx, s.EconomicRepository, &treasurySummaries, data.TreasuryYieldTwoYear.ToTable(), "2Y Treasury Yield", addTreasuryExtras(data.TreasuryYieldTwoYear))
-	add(ctx, s.EconomicRepository, &treasurySummaries, data.TreasuryYieldFiveYear.ToTable(), "5Y Treasury Yield", addTreasuryExtras(data.TreasuryYieldFiveYear))
-	add(ctx, s.EconomicRepository, &treasurySummaries, data.TreasuryYieldSevenYear.ToTable(), "7Y Treasury Yield", addTreasuryExtras(data.TreasuryYieldSevenYear))
-	add(ctx, s.EconomicRepository, &treasurySummaries, data.TreasuryYieldTenYear.ToTable(), "10Y Treasury Yield", addTreasuryExtras(data.TreasuryYieldTenYear))
-	add(ctx, s.EconomicRepository, &treasurySummaries, data.TreasuryYieldThirtyYear.ToTable(), "30Y Treasury Yield", addTreasuryExtras(data.TreasuryYieldThirtyYear))
+// Dashboard gets a dashboard of the user, an id of 0 is the default dashboard
+func (s DashboardService) Dashboard(ctx context.Context, id, userID int64) (*data.Dashboard, error) {
+	if id == 0 {
+		dashboard := DefaultDashboard()
+		return &dashboard, nil
+	}
+	return s.DashboardRepository.Get(ctx, id, userID)
+}
+
+func (s DashboardService) CreateDashboard(ctx context.Context, dashboard *data.Dashboard) error {
+	return s.DashboardRepository.Insert(ctx, dashboard)
+}
 
-	dashData = append(dashData, treasurySummaries...)
-	return &dashData, nil
+func (s DashboardService) UpdateDashboard(ctx context.Context, dashboard *data.Dashboard) error {
+	return s.DashboardRepository.Update(ctx, dashboard)
+}
+
+func (s DashboardService) DeleteDashboard(ctx context.Context, id, userID int64) error {
+	return s.DashboardRepository.Delete(ctx, id, userID)
 }
 
 func addTreasuryExtras(reportType data.ReportType) map[string]interface{} {
 	return map[string]interface{}{"maturity": data.MaturityFromReportType(reportType)}
 }
 
-func add(ctx context.Context, economyRepo data.EconomicRepository, summaries *[]data.Summary, tableName, dashHeader string, extras map[string]interface{}) {
-	if summary := createDashSummary(ctx, economyRepo, tableName, dashHeader, extras); summary != nil {
-		*summaries = append(*summaries, *summary)
+func itemSummary(ctx context.Context, economyRepo data.EconomicRepository, item data.DashboardItem) *data.Summary {
+	report := data.ReportTypeFromSlug(item.Series)
+	name := item.Name
+	if name == "" {
+		name = report.String()
+	}
+	var extras map[string]interface{}
+	// Only the treasury yields have a maturity
+	if data.MaturityFromReportType(report) != "Unknown" {
+		extras = addTreasuryExtras(report)
 	}
+	return createDashSummary(ctx, economyRepo, item.Series, name, data.Change{Type: item.Change, Lag: 1}, extras)
 }
 
-func createDashSummary(ctx context.Context, economyRepo data.EconomicRepository, tableName, dashHeader string, extras map[string]interface{}) *data.Summary {
-	latestWithChange, err := economyRepo.LatestWithPercentChange(ctx, tableName)
+func createDashSummary(ctx context.Context, economyRepo data.EconomicRepository, tableName, dashHeader string, change data.Change, extras map[string]interface{}) *data.Summary {
+	latestWithChange, err := economyRepo.LatestWithPercentChange(ctx, tableName, change)
 	if err != nil {
 		msg := "error getting LatestWithPercentChange data for dashboard summary"
 		utils.Logger(ctx).Error(msg, zap.Error(err),
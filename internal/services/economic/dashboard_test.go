@@ -15,28 +15,95 @@ type MockEconomicRepository struct {
 	mock.Mock
 }
 
-func (w *MockEconomicRepository) LatestWithPercentChange(ctx context.Context, table string) (*data.EconomicWithChange, error) {
-	args := w.Called(ctx, table)
+func (w *MockEconomicRepository) LatestWithPercentChange(ctx context.Context, table string, change data.Change) (*data.EconomicWithChange, error) {
+	args := w.Called(ctx, table, change)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*data.EconomicWithChange), args.Error(1)
 }
 
-func (w *MockEconomicRepository) GetIntervalWithPercentChange(ctx context.Context, table string, years int, paging data.Paging) (*data.EconomicWithChangeResult, error) {
+func (w *MockEconomicRepository) GetIntervalWithPercentChange(ctx context.Context, table string, filter data.SeriesFilter, paging data.Paging) (*data.EconomicWithChangeResult, error) {
 	return nil, nil
 }
 func (w *MockEconomicRepository) GetAll(ctx context.Context, table string) (*[]data.Economic, error) {
-	return nil, nil
+	args := w.Called(ctx, table)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]data.Economic), args.Error(1)
+}
+func (w *MockEconomicRepository) EachWithPercentChange(ctx context.Context, table string, filter data.SeriesFilter, fn func(economic data.EconomicWithChange) error) error {
+	args := w.Called(ctx, table, filter, fn)
+	return args.Error(0)
+}
+func (w *MockEconomicRepository) EachPageWithPercentChange(ctx context.Context, table string, filter data.SeriesFilter, paging data.Paging, start func(meta data.Metadata) error, fn func(economic data.EconomicWithChange) error) error {
+	args := w.Called(ctx, table, filter, paging, start, fn)
+	return args.Error(0)
+}
+func (w *MockEconomicRepository) EachStats(ctx context.Context, table string, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging, start func(meta data.Metadata) error, fn func(stats data.EconomicStats) error) error {
+	args := w.Called(ctx, table, dateRange, timeBucketDays, metrics, paging, start, fn)
+	return args.Error(0)
+}
+func (w *MockEconomicRepository) GetRange(ctx context.Context, table string, filter data.SeriesFilter) (*[]data.Economic, error) {
+	args := w.Called(ctx, table, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]data.Economic), args.Error(1)
+}
+func (w *MockEconomicRepository) GetLatestOnOrBefore(ctx context.Context, table string, date time.Time) (*data.Economic, error) {
+	args := w.Called(ctx, table, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.Economic), args.Error(1)
+}
+func (w *MockEconomicRepository) GetRolling(ctx context.Context, table string, filter data.SeriesFilter, window data.RollingWindow, paging data.Paging) (*data.RollingResult, error) {
+	args := w.Called(ctx, table, filter, window, paging)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.RollingResult), args.Error(1)
+}
+func (w *MockEconomicRepository) GetCoverage(ctx context.Context, table string) (*data.Coverage, error) {
+	args := w.Called(ctx, table)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.Coverage), args.Error(1)
+}
+func (w *MockEconomicRepository) GetVintages(ctx context.Context, table string, date time.Time) (*[]data.Vintage, error) {
+	args := w.Called(ctx, table, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]data.Vintage), args.Error(1)
+}
+func (w *MockEconomicRepository) GetRevisions(ctx context.Context, table string, since time.Time, paging data.Paging) (*data.RevisionSummaryResult, error) {
+	args := w.Called(ctx, table, since, paging)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.RevisionSummaryResult), args.Error(1)
 }
 func (w *MockEconomicRepository) Insert(ctx context.Context, table string, data *data.Economic) error {
 	return nil
 }
+func (w *MockEconomicRepository) Revise(ctx context.Context, table string, data *data.Economic) error {
+	return nil
+}
+func (w *MockEconomicRepository) InsertAnomaly(ctx context.Context, anomaly *data.Anomaly) error {
+	return nil
+}
+func (w *MockEconomicRepository) GetAnomalies(ctx context.Context, since time.Time, paging data.Paging) (*data.AnomalyResult, error) {
+	return nil, nil
+}
 func (w *MockEconomicRepository) InsertMany(ctx context.Context, table string, data *[]data.Economic) error {
 	return nil
 }
 
-func (w *MockEconomicRepository) GetStats(ctx context.Context, table string, years int, timeBucketDays int, paging data.Paging) (*data.EconomicStatsResult, error) {
+func (w *MockEconomicRepository) GetStats(ctx context.Context, table string, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging) (*data.EconomicStatsResult, error) {
 	return nil, nil
 }
 
@@ -46,18 +113,18 @@ func TestDashboardService_GetDashboardSummary(t *testing.T) {
 	date, _ := time.Parse("2006-06-02", "2022-01-01")
 	dashName := "cpi-dash"
 	value := decimal.NewFromFloat(100.00)
-	change := decimal.NewFromFloat(10.00)
+	change := decimal.NewNullDecimal(decimal.NewFromFloat(10.00))
 	slug := "cpi"
 
 	t.Run("createDashSummary", func(t *testing.T) {
 		mockRepo := new(MockEconomicRepository)
-		mockRepo.On("LatestWithPercentChange", mock.Anything, slug).Return(&data.EconomicWithChange{
+		mockRepo.On("LatestWithPercentChange", mock.Anything, slug, data.DefaultChange).Return(&data.EconomicWithChange{
 			Date:   date,
 			Value:  value,
 			Change: change,
 		}, nil).Once()
 
-		res := createDashSummary(ctx, mockRepo, slug, dashName, nil)
+		res := createDashSummary(ctx, mockRepo, slug, dashName, data.DefaultChange, nil)
 		mockRepo.AssertExpectations(t)
 
 		assert.Equal(t, dashName, res.Name)
@@ -77,11 +144,144 @@ func TestDashboardService_GetDashboardSummary_Error(t *testing.T) {
 
 	t.Run("createDashSummary", func(t *testing.T) {
 		mockRepo := new(MockEconomicRepository)
-		mockRepo.On("LatestWithPercentChange", mock.Anything, slug).Return(nil, error).Once()
+		mockRepo.On("LatestWithPercentChange", mock.Anything, slug, data.DefaultChange).Return(nil, error).Once()
+
+		res := createDashSummary(ctx, mockRepo, slug, dashName, data.DefaultChange, nil)
+		mockRepo.AssertExpectations(t)
+
+		assert.Nil(t, res)
+	})
+}
+
+type MockDashboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockDashboardRepository) Insert(ctx context.Context, dashboard *data.Dashboard) error {
+	return m.Called(ctx, dashboard).Error(0)
+}
+func (m *MockDashboardRepository) Get(ctx context.Context, id, userID int64) (*data.Dashboard, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*data.Dashboard), args.Error(1)
+}
+func (m *MockDashboardRepository) GetAllForUser(ctx context.Context, userID int64) (*[]data.Dashboard, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]data.Dashboard), args.Error(1)
+}
+func (m *MockDashboardRepository) Update(ctx context.Context, dashboard *data.Dashboard) error {
+	return m.Called(ctx, dashboard).Error(0)
+}
+func (m *MockDashboardRepository) Delete(ctx context.Context, id, userID int64) error {
+	return m.Called(ctx, id, userID).Error(0)
+}
+
+func TestDashboardService_Summaries(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	latest := &data.EconomicWithChange{Date: date, Value: decimal.NewFromFloat(2.5), Change: decimal.NewNullDecimal(decimal.NewFromFloat(0.1))}
+
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("LatestWithPercentChange", mock.Anything, "treasury_yield_ten_year", data.Change{Type: data.ChangePeriodOverPeriod, Lag: 1}).Return(latest, nil).Once()
+	mockRepo.On("LatestWithPercentChange", mock.Anything, "real_gdp", data.Change{Type: data.ChangeAnnualized, Lag: 1}).Return(nil, errors.New("no data")).Once()
+	mockRepo.On("LatestWithPercentChange", mock.Anything, "cpi", data.Change{Type: data.ChangeYearOverYear, Lag: 1}).Return(latest, nil).Once()
+	s := DashboardService{EconomicRepository: mockRepo}
+
+	res, err := s.Summaries(ctx, data.DashboardItems{
+		{Series: "treasury_yield_ten_year", Change: data.ChangePeriodOverPeriod},
+		{Series: "real_gdp", Change: data.ChangeAnnualized},
+		{Series: "cpi", Change: data.ChangeYearOverYear, Name: "Inflation"},
+	})
+	mockRepo.AssertExpectations(t)
+
+	// The series without data is left out, the others keep the order of the items
+	assert.NoError(t, err)
+	assert.Len(t, *res, 2)
+	assert.Equal(t, "TREASURY_YIELD_TEN_YEAR", (*res)[0].Name)
+	assert.Equal(t, map[string]interface{}{"maturity": data.TreasuryMaturity("10y")}, (*res)[0].Extras)
+	assert.Equal(t, "Inflation", (*res)[1].Name)
+	assert.Equal(t, "cpi", (*res)[1].Slug)
+	assert.Nil(t, (*res)[1].Extras)
+}
+
+func TestDashboardService_GetDashboardSummary_Default(t *testing.T) {
+	mockRepo := new(MockEconomicRepository)
+	// each series is fetched with the change of its item
+	for _, item := range DefaultDashboard().Items {
+		mockRepo.On("LatestWithPercentChange", mock.Anything, item.Series, data.Change{Type: item.Change, Lag: 1}).Return(&data.EconomicWithChange{}, nil).Once()
+	}
+	s := DashboardService{EconomicRepository: mockRepo}
+
+	res, err := s.GetDashboardSummary()
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	names := make([]string, 0, len(*res))
+	for _, summary := range *res {
+		names = append(names, summary.Name)
+	}
+	assert.Equal(t, []string{"CPI", "Consumer Sentiment", "Retail Sales", "3M Treasury Yield", "2Y Treasury Yield",
+		"5Y Treasury Yield", "7Y Treasury Yield", "10Y Treasury Yield", "30Y Treasury Yield"}, names)
+}
+
+func TestDashboardService_Dashboards(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Default", func(t *testing.T) {
+		mockRepo := new(MockDashboardRepository)
+		mockRepo.On("GetAllForUser", mock.Anything, int64(1)).Return(&[]data.Dashboard{}, nil).Once()
+		s := DashboardService{DashboardRepository: mockRepo}
+
+		res, err := s.Dashboards(ctx, 1)
+		mockRepo.AssertExpectations(t)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []data.Dashboard{DefaultDashboard()}, *res)
+	})
+
+	t.Run("User", func(t *testing.T) {
+		dashboards := &[]data.Dashboard{{ID: 7, UserID: 1, Name: "Growth", Items: data.DashboardItems{{Series: "real_gdp", Change: data.ChangeAnnualized}}}}
+		mockRepo := new(MockDashboardRepository)
+		mockRepo.On("GetAllForUser", mock.Anything, int64(1)).Return(dashboards, nil).Once()
+		s := DashboardService{DashboardRepository: mockRepo}
+
+		res, err := s.Dashboards(ctx, 1)
+		mockRepo.AssertExpectations(t)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dashboards, res)
+	})
+}
+
+func TestDashboardService_Dashboard(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Default", func(t *testing.T) {
+		mockRepo := new(MockDashboardRepository)
+		s := DashboardService{DashboardRepository: mockRepo}
+
+		res, err := s.Dashboard(ctx, 0, 1)
+		mockRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), res.ID)
+		assert.Len(t, res.Items, 9)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockDashboardRepository)
+		mockRepo.On("Get", mock.Anything, int64(7), int64(1)).Return(nil, data.ErrRecordNotFound).Once()
+		s := DashboardService{DashboardRepository: mockRepo}
 
-		res := createDashSummary(ctx, mockRepo, slug, dashName, nil)
+		res, err := s.Dashboard(ctx, 7, 1)
 		mockRepo.AssertExpectations(t)
 
+		assert.ErrorIs(t, err, data.ErrRecordNotFound)
 		assert.Nil(t, res)
 	})
 }
@@ -0,0 +1,245 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"math"
+	"time"
+)
+
+const (
+	minForecastObservations = 3
+	forecastDecimalPlaces   = 4
+	// z80 and z95 are the standard normal quantiles of the 80% and 95% prediction intervals
+	z80 = 1.2816
+	z95 = 1.96
+)
+
+type ForecastService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// fittedModel is a model fitted to a series, the residuals are the in-sample one step ahead forecast errors
+type fittedModel struct {
+	residuals []float64
+	alpha     *float64
+	beta      *float64
+	// forecast is the point forecast h steps ahead of the last observation
+	forecast func(h int) float64
+	// stderr is the standard error of the forecast h steps ahead
+	stderr func(h int) float64
+}
+
+// Forecast fits the model to the whole of the report's series and forecasts the horizon of periods after its
+// last observation, returning data.ErrNotEnoughData when the series is too short to fit
+func (s ForecastService) Forecast(ctx context.Context, report data.ReportType, model data.ForecastModel, horizon int) (*data.Forecast, error) {
+	observations, err := s.EconomicRepository.GetAll(ctx, report.ToTable())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting %s data to forecast", report.ToTable())
+	}
+	n := len(*observations)
+	if n < minForecastObservations {
+		return nil, data.ErrNotEnoughData
+	}
+
+	// GetAll is ordered by date descending, the models run oldest first
+	values := make([]float64, n)
+	for i, observation := range *observations {
+		values[n-1-i] = observation.Value.InexactFloat64()
+	}
+	last := (*observations)[0].Date
+
+	var fit fittedModel
+	switch model {
+	case data.ForecastNaive:
+		fit = fitNaive(values)
+	case data.ForecastDrift:
+		fit = fitDrift(values)
+	case data.ForecastETS:
+		fit = fitETS(values)
+	case data.ForecastHolt:
+		fit = fitHolt(values)
+	default:
+		return nil, errors.Errorf("unknown forecast model %q", model)
+	}
+
+	res := data.Forecast{
+		Model:   model,
+		Horizon: horizon,
+		Fit:     forecastFit(fit),
+		Points:  make([]data.ForecastPoint, 0, horizon),
+	}
+	date := last
+	for h := 1; h <= horizon; h++ {
+		date = nextForecastDate(date, report.Frequency())
+		value, stderr := fit.forecast(h), fit.stderr(h)
+		res.Points = append(res.Points, data.ForecastPoint{
+			Date:    date,
+			Value:   forecastDecimal(value),
+			Lower80: forecastDecimal(value - z80*stderr),
+			Upper80: forecastDecimal(value + z80*stderr),
+			Lower95: forecastDecimal(value - z95*stderr),
+			Upper95: forecastDecimal(value + z95*stderr),
+		})
+	}
+	return &res, nil
+}
+
+func fitNaive(values []float64) fittedModel {
+	n := len(values)
+	residuals := make([]float64, 0, n-1)
+	for t := 1; t < n; t++ {
+		residuals = append(residuals, values[t]-values[t-1])
+	}
+	sigma := math.Sqrt(sumOfSquares(residuals) / float64(len(residuals)))
+	return fittedModel{
+		residuals: residuals,
+		forecast:  func(h int) float64 { return values[n-1] },
+		stderr:    func(h int) float64 { return sigma * math.Sqrt(float64(h)) },
+	}
+}
+
+func fitDrift(values []float64) fittedModel {
+	n := len(values)
+	drift := (values[n-1] - values[0]) / float64(n-1)
+	residuals := make([]float64, 0, n-1)
+	for t := 1; t < n; t++ {
+		residuals = append(residuals, values[t]-values[t-1]-drift)
+	}
+	sigma := math.Sqrt(sumOfSquares(residuals) / float64(len(residuals)-1))
+	return fittedModel{
+		residuals: residuals,
+		forecast:  func(h int) float64 { return values[n-1] + float64(h)*drift },
+		stderr: func(h int) float64 {
+			return sigma * math.Sqrt(float64(h)*(1+float64(h)/float64(n)))
+		},
+	}
+}
+
+// fitETS fits simple exponential smoothing, choosing the smoothing parameter which minimises the squared errors
+func fitETS(values []float64) fittedModel {
+	best, bestSSE := 0.0, math.Inf(1)
+	for alpha := 0.01; alpha < 1; alpha += 0.01 {
+		_, residuals := smoothLevel(values, alpha)
+		if sse := sumOfSquares(residuals); sse < bestSSE {
+			best, bestSSE = alpha, sse
+		}
+	}
+	alpha := best
+	level, residuals := smoothLevel(values, alpha)
+	sigma := math.Sqrt(bestSSE / float64(len(residuals)))
+	return fittedModel{
+		residuals: residuals,
+		alpha:     &alpha,
+		forecast:  func(h int) float64 { return level },
+		stderr: func(h int) float64 {
+			return sigma * math.Sqrt(1+float64(h-1)*alpha*alpha)
+		},
+	}
+}
+
+func smoothLevel(values []float64, alpha float64) (float64, []float64) {
+	level := values[0]
+	residuals := make([]float64, 0, len(values)-1)
+	for _, value := range values[1:] {
+		e := value - level
+		residuals = append(residuals, e)
+		level += alpha * e
+	}
+	return level, residuals
+}
+
+// fitHolt fits Holt's linear trend method, choosing the level and trend smoothing parameters which minimise the
+// squared errors
+func fitHolt(values []float64) fittedModel {
+	bestAlpha, bestBeta, bestSSE := 0.0, 0.0, math.Inf(1)
+	for alpha := 0.05; alpha < 1; alpha += 0.05 {
+		for beta := 0.05; beta < 1; beta += 0.05 {
+			_, _, residuals := smoothTrend(values, alpha, beta)
+			if sse := sumOfSquares(residuals); sse < bestSSE {
+				bestAlpha, bestBeta, bestSSE = alpha, beta, sse
+			}
+		}
+	}
+	alpha, beta := bestAlpha, bestBeta
+	level, trend, residuals := smoothTrend(values, alpha, beta)
+	sigma := math.Sqrt(bestSSE / float64(len(residuals)))
+	// The variance uses the error correction form of the trend smoothing parameter, alpha * beta
+	b := alpha * beta
+	return fittedModel{
+		residuals: residuals,
+		alpha:     &alpha,
+		beta:      &beta,
+		forecast:  func(h int) float64 { return level + float64(h)*trend },
+		stderr: func(h int) float64 {
+			fh := float64(h)
+			return sigma * math.Sqrt(1+(fh-1)*(alpha*alpha+alpha*b*fh+b*b*fh*(2*fh-1)/6))
+		},
+	}
+}
+
+func smoothTrend(values []float64, alpha, beta float64) (float64, float64, []float64) {
+	level, trend := values[0], values[1]-values[0]
+	residuals := make([]float64, 0, len(values)-1)
+	for _, value := range values[1:] {
+		e := value - (level + trend)
+		residuals = append(residuals, e)
+		level += trend + alpha*e
+		trend += alpha * beta * e
+	}
+	return level, trend, residuals
+}
+
+func forecastFit(fit fittedModel) data.ForecastFit {
+	abs := 0.0
+	for _, e := range fit.residuals {
+		abs += math.Abs(e)
+	}
+	n := float64(len(fit.residuals))
+	res := data.ForecastFit{
+		Observations: len(fit.residuals) + 1,
+		MAE:          forecastDecimal(abs / n),
+		RMSE:         forecastDecimal(math.Sqrt(sumOfSquares(fit.residuals) / n)),
+	}
+	if fit.alpha != nil {
+		alpha := decimal.NewFromFloat(*fit.alpha).Round(2)
+		res.Alpha = &alpha
+	}
+	if fit.beta != nil {
+		beta := decimal.NewFromFloat(*fit.beta).Round(2)
+		res.Beta = &beta
+	}
+	return res
+}
+
+// nextForecastDate steps to the next period of the report's frequency, daily series skip weekends
+func nextForecastDate(date time.Time, frequency string) time.Time {
+	switch frequency {
+	case "daily":
+		next := date.AddDate(0, 0, 1)
+		for next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	case "quarterly":
+		return date.AddDate(0, 3, 0)
+	case "annual":
+		return date.AddDate(1, 0, 0)
+	default:
+		return date.AddDate(0, 1, 0)
+	}
+}
+
+func sumOfSquares(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v * v
+	}
+	return sum
+}
+
+func forecastDecimal(value float64) decimal.Decimal {
+	return decimal.NewFromFloat(value).Round(forecastDecimalPlaces)
+}
@@ -0,0 +1,79 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// linearSeries is a monthly series rising by 2 a month, ordered by date descending like GetAll
+func linearSeries(n int) []data.Economic {
+	series := make([]data.Economic, n)
+	for i := 0; i < n; i++ {
+		series[n-1-i] = data.Economic{Date: month(i + 1), Value: decimal.NewFromInt(int64(100 + 2*i))}
+	}
+	return series
+}
+
+func TestForecastService_Forecast(t *testing.T) {
+	ctx := context.Background()
+	series := linearSeries(24)
+
+	tests := []struct {
+		name  string
+		model data.ForecastModel
+		want  []string
+	}{
+		{name: "Naive", model: data.ForecastNaive, want: []string{"146", "146", "146"}},
+		{name: "Drift", model: data.ForecastDrift, want: []string{"148", "150", "152"}},
+		{name: "Holt", model: data.ForecastHolt, want: []string{"148", "150", "152"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEconomicRepository)
+			mockRepo.On("GetAll", mock.Anything, "cpi").Return(&series, nil).Once()
+
+			forecast, err := ForecastService{EconomicRepository: mockRepo}.Forecast(ctx, data.CPI, tt.model, 3)
+			mockRepo.AssertExpectations(t)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 24, forecast.Fit.Observations)
+			assert.Len(t, forecast.Points, 3)
+			assert.Equal(t, month(25), forecast.Points[0].Date)
+			for i, want := range tt.want {
+				assert.Equal(t, want, forecast.Points[i].Value.String())
+				assert.True(t, forecast.Points[i].Lower95.LessThanOrEqual(forecast.Points[i].Lower80))
+				assert.True(t, forecast.Points[i].Upper80.LessThanOrEqual(forecast.Points[i].Upper95))
+			}
+		})
+	}
+
+	t.Run("Not Enough Data", func(t *testing.T) {
+		short := linearSeries(2)
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("GetAll", mock.Anything, "cpi").Return(&short, nil).Once()
+
+		_, err := ForecastService{EconomicRepository: mockRepo}.Forecast(ctx, data.CPI, data.ForecastNaive, 3)
+		assert.ErrorIs(t, err, data.ErrNotEnoughData)
+	})
+}
+
+func TestFitETS(t *testing.T) {
+	fit := fitETS([]float64{5, 5, 5, 5, 5})
+
+	assert.Equal(t, 5.0, fit.forecast(4))
+	assert.Equal(t, 0.0, fit.stderr(4))
+	assert.NotNil(t, fit.alpha)
+}
+
+func TestNextForecastDate(t *testing.T) {
+	friday := time.Date(2022, 8, 19, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, friday.AddDate(0, 0, 3), nextForecastDate(friday, "daily"))
+	assert.Equal(t, friday.AddDate(0, 3, 0), nextForecastDate(friday, "quarterly"))
+	assert.Equal(t, friday.AddDate(1, 0, 0), nextForecastDate(friday, "annual"))
+}
@@ -0,0 +1,119 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"math"
+	"time"
+)
+
+const (
+	// sahmLookbackMonths is the three month average plus the twelve months of prior averages it is compared to
+	sahmLookbackMonths = 14
+	// probitAlpha and probitBeta are the coefficients of the New York Fed's yield curve recession model
+	probitAlpha = -0.5333
+	probitBeta  = -0.6330
+	// recessionHorizonMonths is how far ahead the probit model forecasts
+	recessionHorizonMonths = 12
+)
+
+var (
+	sahmThreshold = decimal.NewFromFloat(0.5)
+	// probabilityThreshold is the probability every recession since the 1960s has been preceded by
+	probabilityThreshold = decimal.NewFromFloat(0.3)
+	three                = decimal.NewFromInt(3)
+)
+
+type IndicatorService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// Recession calculates the Sahm rule and the yield curve recession probability over the date range, the series
+// are ordered by date descending
+func (s IndicatorService) Recession(ctx context.Context, dateRange data.DateRange) (*data.RecessionIndicators, error) {
+	lookback := dateRange
+	lookback.From = dateRange.From.AddDate(0, -sahmLookbackMonths-1, 0)
+	unemployment, err := s.EconomicRepository.GetRange(ctx, data.Unemployment.ToTable(), data.SeriesFilter{DateRange: lookback})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting unemployment for the sahm rule")
+	}
+
+	monthly := data.SeriesFilter{DateRange: dateRange, Frequency: data.FrequencyMonthly, Aggregation: data.AggregationMean}
+	series := make(map[string][]data.Economic, 2)
+	for _, report := range []data.ReportType{data.TreasuryYieldTenYear, data.TreasuryYieldThreeMonth} {
+		observations, err := s.EconomicRepository.GetRange(ctx, report.ToTable(), monthly)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting %s for the recession probability", report.ToTable())
+		}
+		series[report.ToTable()] = *observations
+	}
+
+	res := data.RecessionIndicators{
+		Sahm:        sahmRule(*unemployment, dateRange.From),
+		Probability: recessionProbability(series[data.TreasuryYieldTenYear.ToTable()], series[data.TreasuryYieldThreeMonth.ToTable()]),
+	}
+	if len(res.Sahm) > 0 {
+		res.Current.Sahm = &res.Sahm[0]
+	}
+	if len(res.Probability) > 0 {
+		res.Current.Probability = &res.Probability[0]
+	}
+	return &res, nil
+}
+
+// sahmRule calculates the rule for each month from the start date with a full lookback, the unemployment
+// observations are ordered by date descending
+func sahmRule(unemployment []data.Economic, from time.Time) []data.SahmReading {
+	n := len(unemployment)
+	averages := make([]decimal.Decimal, n)
+	for i := n - 3; i >= 0; i-- {
+		averages[i] = unemployment[i].Value.Add(unemployment[i+1].Value).Add(unemployment[i+2].Value).Div(three)
+	}
+
+	readings := []data.SahmReading{}
+	for i := 0; i+sahmLookbackMonths < n; i++ {
+		if unemployment[i].Date.Before(from) {
+			break
+		}
+		low := averages[i+1]
+		for j := i + 2; j <= i+12; j++ {
+			low = decimal.Min(low, averages[j])
+		}
+		value := averages[i].Sub(low)
+		readings = append(readings, data.SahmReading{
+			Date:              unemployment[i].Date,
+			Unemployment:      unemployment[i].Value,
+			ThreeMonthAverage: averages[i].Round(2),
+			PriorLow:          low.Round(2),
+			Value:             value.Round(2),
+			Triggered:         value.GreaterThanOrEqual(sahmThreshold),
+		})
+	}
+	return readings
+}
+
+// recessionProbability applies the probit model to the spread of the monthly average yields, the yields are
+// ordered by date descending
+func recessionProbability(long, short []data.Economic) []data.RecessionProbability {
+	rows := alignSeries([]string{"long", "short"}, map[string][]data.Economic{"long": long, "short": short}, data.AlignInner)
+
+	probabilities := make([]data.RecessionProbability, 0, len(rows))
+	for _, row := range rows {
+		spread := row.Values["long"].Sub(*row.Values["short"])
+		probability := decimal.NewFromFloat(normalCDF(probitAlpha + probitBeta*spread.InexactFloat64())).Round(4)
+		probabilities = append(probabilities, data.RecessionProbability{
+			Date:         row.Date,
+			ForecastDate: row.Date.AddDate(0, recessionHorizonMonths, 0),
+			SpreadPct:    spread.Round(2),
+			Probability:  probability,
+			Triggered:    probability.GreaterThanOrEqual(probabilityThreshold),
+		})
+	}
+	return probabilities
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
@@ -0,0 +1,51 @@
+package economic
+
+import (
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func month(m int) time.Time {
+	return time.Date(2021, time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSahmRule(t *testing.T) {
+	// Oldest first, then reversed to the descending order of the repository
+	values := []float64{3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.5, 3.8, 4.0, 4.2}
+	unemployment := make([]data.Economic, len(values))
+	for i, value := range values {
+		unemployment[len(values)-1-i] = data.Economic{Date: month(i + 1), Value: decimal.NewFromFloat(value)}
+	}
+
+	readings := sahmRule(unemployment, month(1))
+
+	assert.Len(t, readings, 2)
+	assert.Equal(t, month(16), readings[0].Date)
+	assert.Equal(t, "4", readings[0].ThreeMonthAverage.String())
+	assert.Equal(t, "3.5", readings[0].PriorLow.String())
+	assert.Equal(t, "0.5", readings[0].Value.String())
+	assert.True(t, readings[0].Triggered)
+	assert.Equal(t, "0.27", readings[1].Value.String())
+	assert.False(t, readings[1].Triggered)
+
+	assert.Len(t, sahmRule(unemployment, month(16)), 1)
+}
+
+func TestRecessionProbability(t *testing.T) {
+	long := []data.Economic{observation(2, 3.9), observation(1, 4.0)}
+	short := []data.Economic{observation(2, 4.0), observation(1, 4.0)}
+
+	probabilities := recessionProbability(long, short)
+
+	assert.Len(t, probabilities, 2)
+	assert.Equal(t, day(2), probabilities[0].Date)
+	assert.Equal(t, day(2).AddDate(1, 0, 0), probabilities[0].ForecastDate)
+	assert.Equal(t, "-0.1", probabilities[0].SpreadPct.String())
+	assert.Equal(t, "0.3192", probabilities[0].Probability.String())
+	assert.True(t, probabilities[0].Triggered)
+	assert.Equal(t, "0.2969", probabilities[1].Probability.String())
+	assert.False(t, probabilities[1].Triggered)
+}
@@ -0,0 +1,52 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type RevisionService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// History gets every vintage of the report's observation on the date, returning data.ErrRecordNotFound when
+// there is no observation on the date
+func (s RevisionService) History(ctx context.Context, report data.ReportType, date time.Time) (*data.RevisionHistory, error) {
+	vintages, err := s.EconomicRepository.GetVintages(ctx, report.ToTable(), date)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting %s vintages", report.ToTable())
+	}
+	if len(*vintages) == 0 {
+		return nil, data.ErrRecordNotFound
+	}
+	history := revisionHistory(date, *vintages)
+	return &history, nil
+}
+
+// Summary gets the report's observations revised since the date, largest revision first
+func (s RevisionService) Summary(ctx context.Context, report data.ReportType, since time.Time, paging data.Paging) (*data.RevisionSummaryResult, error) {
+	res, err := s.EconomicRepository.GetRevisions(ctx, report.ToTable(), since, paging)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting %s revisions", report.ToTable())
+	}
+	return res, nil
+}
+
+// revisionHistory adds the change from the previous vintage to each revision, the vintages are ordered oldest first
+func revisionHistory(date time.Time, vintages []data.Vintage) data.RevisionHistory {
+	for i := 1; i < len(vintages); i++ {
+		change := vintages[i].Value.Sub(vintages[i-1].Value)
+		vintages[i].Change = &change
+	}
+	first := vintages[0].Value
+	current := vintages[len(vintages)-1].Value
+	return data.RevisionHistory{
+		Date:       date,
+		FirstPrint: first,
+		Current:    current,
+		Revision:   current.Sub(first),
+		Vintages:   vintages,
+	}
+}
@@ -0,0 +1,37 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestRevisionService_History(t *testing.T) {
+	ctx := context.Background()
+	revised := day(20)
+	vintages := []data.Vintage{
+		{Value: decimal.NewFromInt(263), RealtimeStart: day(5), RealtimeEnd: &revised},
+		{Value: decimal.NewFromInt(315), RealtimeStart: revised},
+	}
+
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("GetVintages", mock.Anything, "nonfarm_payrolls", day(1)).Return(&vintages, nil).Once()
+	mockRepo.On("GetVintages", mock.Anything, "nonfarm_payrolls", day(2)).Return(&[]data.Vintage{}, nil).Once()
+
+	s := RevisionService{EconomicRepository: mockRepo}
+	history, err := s.History(ctx, data.NonfarmPayroll, day(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "263", history.FirstPrint.String())
+	assert.Equal(t, "315", history.Current.String())
+	assert.Equal(t, "52", history.Revision.String())
+	assert.Nil(t, history.Vintages[0].Change)
+	assert.Equal(t, "52", history.Vintages[1].Change.String())
+
+	_, err = s.History(ctx, data.NonfarmPayroll, day(2))
+	assert.ErrorIs(t, err, data.ErrRecordNotFound)
+	mockRepo.AssertExpectations(t)
+}
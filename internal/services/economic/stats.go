@@ -0,0 +1,99 @@
+package economic
+
+import (
+	"math"
+	"sort"
+)
+
+// pearson is the Pearson correlation coefficient of the paired samples, false when it is undefined
+// because there are fewer than three pairs or either sample is constant
+func pearson(x, y []float64) (float64, bool) {
+	n := len(x)
+	if n < 3 || n != len(y) {
+		return 0, false
+	}
+
+	meanX, meanY := mean(x), mean(y)
+	var cov, varX, varY float64
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
+
+// spearman is the Spearman rank correlation coefficient, the Pearson coefficient of the ranks
+func spearman(x, y []float64) (float64, bool) {
+	return pearson(ranks(x), ranks(y))
+}
+
+// ranks gives each value its 1 based rank, tied values get the average of the ranks they span
+func ranks(values []float64) []float64 {
+	idx := make([]int, len(values))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return values[idx[i]] < values[idx[j]] })
+
+	res := make([]float64, len(values))
+	for i := 0; i < len(idx); {
+		j := i
+		for j+1 < len(idx) && values[idx[j+1]] == values[idx[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			res[idx[k]] = rank
+		}
+		i = j + 1
+	}
+	return res
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// robustZScore is how many scaled median absolute deviations the value is from the median of the sample, falling
+// back to the mean absolute deviation when most of the sample is the same value, false when the sample has no spread
+func robustZScore(value float64, sample []float64) (float64, bool) {
+	m := median(sample)
+	deviations := make([]float64, len(sample))
+	for i, v := range sample {
+		deviations[i] = math.Abs(v - m)
+	}
+	// 1.4826 and 1.2533 scale the deviations to the standard deviation of normally distributed data
+	spread := 1.4826 * median(deviations)
+	if spread == 0 {
+		spread = 1.2533 * mean(deviations)
+	}
+	if spread == 0 {
+		return 0, false
+	}
+	return (value - m) / spread, true
+}
@@ -0,0 +1,52 @@
+package economic
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPearson(t *testing.T) {
+	r, ok := pearson([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8})
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, r, 1e-9)
+
+	r, ok = pearson([]float64{1, 2, 3, 4}, []float64{8, 6, 4, 2})
+	assert.True(t, ok)
+	assert.InDelta(t, -1.0, r, 1e-9)
+
+	_, ok = pearson([]float64{1, 2, 3}, []float64{5, 5, 5})
+	assert.False(t, ok, "constant sample has no correlation")
+
+	_, ok = pearson([]float64{1, 2}, []float64{1, 2})
+	assert.False(t, ok, "too few observations")
+}
+
+func TestSpearman(t *testing.T) {
+	// Monotonic but not linear, so only the rank correlation is perfect
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{1, 4, 9, 16, 100}
+
+	r, ok := spearman(x, y)
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, r, 1e-9)
+
+	p, _ := pearson(x, y)
+	assert.Less(t, p, 1.0)
+}
+
+func TestRanks(t *testing.T) {
+	assert.Equal(t, []float64{1, 2.5, 2.5, 4}, ranks([]float64{1, 3, 3, 7}))
+	assert.Equal(t, []float64{3, 1, 2}, ranks([]float64{30, 10, 20}))
+}
+
+func TestRobustZScore(t *testing.T) {
+	sample := []float64{1, 2, 3, 4, 100}
+
+	z, ok := robustZScore(10, sample)
+	assert.True(t, ok)
+	assert.InDelta(t, 4.72, z, 0.01)
+
+	_, ok = robustZScore(10, []float64{2, 2, 2})
+	assert.False(t, ok)
+	assert.Equal(t, 2.5, median([]float64{4, 1, 3, 2}))
+}
@@ -0,0 +1,180 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+const (
+	// subscriptionBuffer is how many events a subscriber can fall behind before it is dropped
+	subscriptionBuffer = 64
+	// recentEvents is how many event ids the broker remembers to drop the notification of an event it published itself
+	recentEvents = 1024
+	// MaxReplayEvents is the most events replayed to a reconnecting client at once
+	MaxReplayEvents = 1000
+)
+
+// EventSubscription receives the events of its series, Events is closed when the subscription is closed by the
+// subscriber or dropped by the broker for falling behind
+type EventSubscription struct {
+	Events <-chan data.EconomicEvent
+	events chan data.EconomicEvent
+	slugs  map[string]bool
+}
+
+// EventBroker is the in-process pub/sub of the economic events, fed by the data sync of this replica and the
+// notifications of every replica
+type EventBroker struct {
+	mu            sync.Mutex
+	subscriptions map[*EventSubscription]struct{}
+	recent        map[int64]struct{}
+	recentOrder   []int64
+}
+
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		subscriptions: make(map[*EventSubscription]struct{}),
+		recent:        make(map[int64]struct{}, recentEvents),
+	}
+}
+
+// Subscribe subscribes to the events of the series
+func (b *EventBroker) Subscribe(slugs []string) *EventSubscription {
+	events := make(chan data.EconomicEvent, subscriptionBuffer)
+	sub := &EventSubscription{Events: events, events: events, slugs: make(map[string]bool, len(slugs))}
+	for _, slug := range slugs {
+		sub.slugs[slug] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe closes the subscription, it is a no-op when the broker has already dropped it
+func (b *EventBroker) Unsubscribe(sub *EventSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.close(sub)
+}
+
+// Publish sends the event to the subscribers of its series. An event already published is ignored, as the
+// replica which synced it publishes it and then gets the notification of it too. A subscriber whose buffer is full
+// is dropped rather than blocking the others, it catches up by reconnecting with the id of the last event it got
+func (b *EventBroker) Publish(event data.EconomicEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.recent[event.ID]; ok {
+		return
+	}
+	b.recent[event.ID] = struct{}{}
+	b.recentOrder = append(b.recentOrder, event.ID)
+	if len(b.recentOrder) > recentEvents {
+		delete(b.recent, b.recentOrder[0])
+		b.recentOrder = b.recentOrder[1:]
+	}
+
+	for sub := range b.subscriptions {
+		if !sub.slugs[event.Slug] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.close(sub)
+		}
+	}
+}
+
+func (b *EventBroker) close(sub *EventSubscription) {
+	if _, ok := b.subscriptions[sub]; ok {
+		delete(b.subscriptions, sub)
+		close(sub.events)
+	}
+}
+
+// invalidateOnEvents calls invalidate with the series of each event published by the sync of every replica, until the
+// context is done. The broker drops a subscriber which falls behind, the events it missed are unknown so invalidateAll
+// is called before subscribing again
+func invalidateOnEvents(ctx context.Context, broker *EventBroker, invalidate func(slug string), invalidateAll func()) {
+	slugs := make([]string, 0, len(data.ReportTypes()))
+	for _, report := range data.ReportTypes() {
+		slugs = append(slugs, report.ToTable())
+	}
+
+	for {
+		sub := broker.Subscribe(slugs)
+		if !invalidateEvents(ctx, sub, invalidate) {
+			broker.Unsubscribe(sub)
+			return
+		}
+		invalidateAll()
+	}
+}
+
+// invalidateEvents invalidates the series of the events of the subscription, it returns false when the context is
+// done and true when the subscription was dropped
+func invalidateEvents(ctx context.Context, sub *EventSubscription, invalidate func(slug string)) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-sub.Events:
+			if !ok {
+				return true
+			}
+			invalidate(event.Slug)
+		}
+	}
+}
+
+type StreamService struct {
+	EventRepository data.EventRepository
+	Broker          *EventBroker
+}
+
+// Publish stores the event, so it can be replayed, and publishes it to this replica's subscribers
+func (s StreamService) Publish(ctx context.Context, event *data.EconomicEvent) error {
+	err := s.EventRepository.Insert(ctx, event)
+	if err != nil {
+		return errors.Wrapf(err, "error storing %s event for %s", event.Kind, event.Slug)
+	}
+	s.Broker.Publish(*event)
+	return nil
+}
+
+func (s StreamService) Subscribe(slugs []string) *EventSubscription {
+	return s.Broker.Subscribe(slugs)
+}
+
+func (s StreamService) Unsubscribe(sub *EventSubscription) {
+	s.Broker.Unsubscribe(sub)
+}
+
+// Replay gets the events of the series after the id, oldest first
+func (s StreamService) Replay(ctx context.Context, afterID int64, slugs []string) (*[]data.EconomicEvent, error) {
+	events, err := s.EventRepository.GetAfter(ctx, afterID, slugs, MaxReplayEvents)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting events to replay")
+	}
+	return events, nil
+}
+
+// LatestID gets the id of the latest event, a client without a Last-Event-ID is sent it so it gets the events after
+// it when it reconnects
+func (s StreamService) LatestID(ctx context.Context) (int64, error) {
+	id, err := s.EventRepository.GetLatestID(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "error getting latest event id")
+	}
+	return id, nil
+}
+
+// Listen publishes the events notified by every replica until the context is done
+func (s StreamService) Listen(ctx context.Context, listener data.EventListener) error {
+	return listener.Listen(ctx, s.Broker.Publish)
+}
@@ -0,0 +1,51 @@
+package economic
+
+import (
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEventBroker_Publish(t *testing.T) {
+	broker := NewEventBroker()
+	cpi := broker.Subscribe([]string{"cpi"})
+	all := broker.Subscribe([]string{"cpi", "unemployment"})
+
+	broker.Publish(data.EconomicEvent{ID: 1, Slug: "cpi", Kind: data.EventInserted})
+	broker.Publish(data.EconomicEvent{ID: 2, Slug: "unemployment", Kind: data.EventRevised})
+	// the notification of an event this replica published is dropped
+	broker.Publish(data.EconomicEvent{ID: 1, Slug: "cpi", Kind: data.EventInserted})
+
+	assert.Len(t, cpi.Events, 1)
+	assert.Equal(t, int64(1), (<-cpi.Events).ID)
+	assert.Len(t, all.Events, 2)
+	assert.Equal(t, int64(1), (<-all.Events).ID)
+	assert.Equal(t, int64(2), (<-all.Events).ID)
+
+	broker.Unsubscribe(cpi)
+	_, open := <-cpi.Events
+	assert.False(t, open)
+	// unsubscribing twice is a no-op
+	broker.Unsubscribe(cpi)
+}
+
+func TestEventBroker_DropsSlowSubscriber(t *testing.T) {
+	broker := NewEventBroker()
+	slow := broker.Subscribe([]string{"cpi"})
+	fast := broker.Subscribe([]string{"cpi"})
+
+	for id := int64(1); id <= subscriptionBuffer+1; id++ {
+		broker.Publish(data.EconomicEvent{ID: id, Slug: "cpi"})
+		if id <= subscriptionBuffer {
+			<-fast.Events
+		}
+	}
+
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	assert.Equal(t, subscriptionBuffer, received)
+	assert.Len(t, fast.Events, 1)
+	broker.Unsubscribe(slow)
+}
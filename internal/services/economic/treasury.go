@@ -0,0 +1,136 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+var basisPoints = decimal.NewFromInt(100)
+
+// maxCurveStaleness is how far before the date of the curve a maturity's yield can be observed, enough to span a
+// weekend and a holiday. An older yield is left out, as the maturity wasn't being published on the date
+const maxCurveStaleness = 5 * 24 * time.Hour
+
+type TreasuryService struct {
+	EconomicRepository data.EconomicRepository
+}
+
+// YieldCurve gets the yield of every maturity on the date, falling back to the nearest prior observation
+// when a maturity has no data on the date itself, e.g. weekends and holidays. A maturity without an observation within
+// maxCurveStaleness of the date is left out of the curve
+func (s TreasuryService) YieldCurve(ctx context.Context, date time.Time) (*data.YieldCurve, error) {
+	curve := data.YieldCurve{Date: date, Points: make([]data.YieldCurvePoint, 0, len(data.TreasuryMaturities))}
+
+	for _, maturity := range data.TreasuryMaturities {
+		report := data.ReportTypeTreasuryYieldMaturity(string(maturity))
+		observation, err := s.EconomicRepository.GetLatestOnOrBefore(ctx, report.ToTable(), date)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "error getting %s treasury yield for the curve", maturity)
+		}
+		if date.Sub(observation.Date) > maxCurveStaleness {
+			continue
+		}
+		curve.Points = append(curve.Points, data.YieldCurvePoint{
+			Maturity: data.MaturityFromReportType(report),
+			Date:     observation.Date,
+			Value:    observation.Value,
+		})
+	}
+
+	if len(curve.Points) == 0 {
+		return nil, data.ErrRecordNotFound
+	}
+	return &curve, nil
+}
+
+// YieldCurveShift is the move of each maturity from the compare curve to the curve, in basis points
+func (s TreasuryService) YieldCurveShift(curve, compare *data.YieldCurve) []data.YieldCurveShift {
+	from := make(map[data.TreasuryMaturity]decimal.Decimal, len(compare.Points))
+	for _, point := range compare.Points {
+		from[point.Maturity] = point.Value
+	}
+
+	shifts := make([]data.YieldCurveShift, 0, len(curve.Points))
+	for _, point := range curve.Points {
+		fromValue, ok := from[point.Maturity]
+		if !ok {
+			continue
+		}
+		shifts = append(shifts, data.YieldCurveShift{
+			Maturity:  point.Maturity,
+			From:      fromValue,
+			To:        point.Value,
+			ChangeBps: point.Value.Sub(fromValue).Mul(basisPoints),
+		})
+	}
+	return shifts
+}
+
+// YieldSpread gets the long minus short maturity spread in basis points on the dates both have a yield,
+// along with the episodes where the curve between them was inverted
+func (s TreasuryService) YieldSpread(ctx context.Context, long, short data.TreasuryMaturity, dateRange data.DateRange) (*data.YieldSpreadResult, error) {
+	longReport := data.ReportTypeTreasuryYieldMaturity(string(long))
+	shortReport := data.ReportTypeTreasuryYieldMaturity(string(short))
+	filter := data.SeriesFilter{DateRange: dateRange}
+
+	series := make(map[string][]data.Economic, 2)
+	for _, report := range []data.ReportType{longReport, shortReport} {
+		observations, err := s.EconomicRepository.GetRange(ctx, report.ToTable(), filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting %s data for the yield spread", report.ToTable())
+		}
+		series[report.ToTable()] = *observations
+	}
+
+	rows := alignSeries([]string{longReport.ToTable(), shortReport.ToTable()}, series, data.AlignInner)
+	spreads := make([]data.YieldSpread, 0, len(rows))
+	for _, row := range rows {
+		spread := row.Values[longReport.ToTable()].Sub(*row.Values[shortReport.ToTable()]).Mul(basisPoints)
+		spreads = append(spreads, data.YieldSpread{Date: row.Date, SpreadBps: spread})
+	}
+
+	return &data.YieldSpreadResult{
+		Long:       long,
+		Short:      short,
+		Spreads:    spreads,
+		Inversions: inversionEpisodes(spreads),
+	}, nil
+}
+
+// inversionEpisodes finds the runs of negative spreads, the spreads are ordered by date descending
+func inversionEpisodes(spreads []data.YieldSpread) []data.InversionEpisode {
+	episodes := []data.InversionEpisode{}
+	var current *data.InversionEpisode
+
+	for i := len(spreads) - 1; i >= 0; i-- {
+		spread := spreads[i]
+		if !spread.SpreadBps.IsNegative() {
+			if current != nil {
+				episodes = append(episodes, *current)
+				current = nil
+			}
+			continue
+		}
+		if current == nil {
+			current = &data.InversionEpisode{Start: spread.Date, DeepestDate: spread.Date, DeepestBps: spread.SpreadBps}
+		}
+		current.End = spread.Date
+		current.Observations++
+		current.DurationDays = int(current.End.Sub(current.Start).Hours()/24) + 1
+		if spread.SpreadBps.LessThan(current.DeepestBps) {
+			current.DeepestDate = spread.Date
+			current.DeepestBps = spread.SpreadBps
+		}
+	}
+	if current != nil {
+		current.Ongoing = true
+		episodes = append(episodes, *current)
+	}
+	return episodes
+}
@@ -0,0 +1,129 @@
+package economic
+
+import (
+	"context"
+	"github.com/mhamm84/pulse-api/internal/data"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestTreasuryService_YieldCurve(t *testing.T) {
+	ctx := context.Background()
+	saturday := time.Date(2022, 8, 20, 0, 0, 0, 0, time.UTC)
+	friday := saturday.AddDate(0, 0, -1)
+
+	mockRepo := new(MockEconomicRepository)
+	mockRepo.On("GetLatestOnOrBefore", mock.Anything, "treasury_yield_thirty_year", saturday).Return(nil, data.ErrRecordNotFound).Once()
+	mockRepo.On("GetLatestOnOrBefore", mock.Anything, mock.Anything, saturday).Return(&data.Economic{
+		Date:  friday,
+		Value: decimal.NewFromFloat(3.1),
+	}, nil).Times(5)
+
+	s := TreasuryService{EconomicRepository: mockRepo}
+	curve, err := s.YieldCurve(ctx, saturday)
+	mockRepo.AssertExpectations(t)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saturday, curve.Date)
+	assert.Len(t, curve.Points, 5)
+	assert.Equal(t, data.TreasuryMaturity("3m"), curve.Points[0].Maturity)
+	assert.Equal(t, friday, curve.Points[0].Date)
+}
+
+func TestTreasuryService_YieldCurve_Stale(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2022, 8, 22, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		observed time.Time
+		included bool
+	}{
+		{name: "Same Day", observed: date, included: true},
+		{name: "Long Weekend", observed: date.AddDate(0, 0, -4), included: true},
+		{name: "Stale", observed: date.AddDate(0, 0, -6), included: false},
+		{name: "Discontinued", observed: date.AddDate(-4, 0, 0), included: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEconomicRepository)
+			mockRepo.On("GetLatestOnOrBefore", mock.Anything, "treasury_yield_thirty_year", date).Return(&data.Economic{
+				Date:  tt.observed,
+				Value: decimal.NewFromFloat(3.3),
+			}, nil).Once()
+			mockRepo.On("GetLatestOnOrBefore", mock.Anything, mock.Anything, date).Return(&data.Economic{
+				Date:  date,
+				Value: decimal.NewFromFloat(3.1),
+			}, nil).Times(5)
+
+			s := TreasuryService{EconomicRepository: mockRepo}
+			curve, err := s.YieldCurve(ctx, date)
+			mockRepo.AssertExpectations(t)
+
+			assert.NoError(t, err)
+			last := curve.Points[len(curve.Points)-1]
+			assert.Equal(t, tt.included, last.Maturity == "30y")
+			if tt.included {
+				assert.Len(t, curve.Points, 6)
+				assert.Equal(t, tt.observed, last.Date)
+			} else {
+				assert.Len(t, curve.Points, 5)
+			}
+		})
+	}
+
+	t.Run("All Stale", func(t *testing.T) {
+		mockRepo := new(MockEconomicRepository)
+		mockRepo.On("GetLatestOnOrBefore", mock.Anything, mock.Anything, date).Return(&data.Economic{Date: date.AddDate(0, -1, 0)}, nil).Times(6)
+
+		s := TreasuryService{EconomicRepository: mockRepo}
+		_, err := s.YieldCurve(ctx, date)
+
+		assert.ErrorIs(t, err, data.ErrRecordNotFound)
+	})
+}
+
+func TestYieldCurveShift(t *testing.T) {
+	curve := &data.YieldCurve{Points: []data.YieldCurvePoint{
+		{Maturity: "2y", Value: decimal.NewFromFloat(3.25)},
+		{Maturity: "10y", Value: decimal.NewFromFloat(2.9)},
+	}}
+	compare := &data.YieldCurve{Points: []data.YieldCurvePoint{
+		{Maturity: "10y", Value: decimal.NewFromFloat(1.5)},
+	}}
+
+	shifts := TreasuryService{}.YieldCurveShift(curve, compare)
+
+	assert.Len(t, shifts, 1)
+	assert.Equal(t, data.TreasuryMaturity("10y"), shifts[0].Maturity)
+	assert.Equal(t, "140", shifts[0].ChangeBps.String())
+}
+
+func TestInversionEpisodes(t *testing.T) {
+	spread := func(d int, bps int64) data.YieldSpread {
+		return data.YieldSpread{Date: day(d), SpreadBps: decimal.NewFromInt(bps)}
+	}
+	spreads := []data.YieldSpread{
+		spread(7, -5),
+		spread(6, 10),
+		spread(5, -20),
+		spread(4, -45),
+		spread(3, -10),
+		spread(2, 0),
+		spread(1, 15),
+	}
+
+	episodes := inversionEpisodes(spreads)
+
+	assert.Len(t, episodes, 2)
+	assert.Equal(t, day(3), episodes[0].Start)
+	assert.Equal(t, day(5), episodes[0].End)
+	assert.Equal(t, 3, episodes[0].DurationDays)
+	assert.Equal(t, day(4), episodes[0].DeepestDate)
+	assert.Equal(t, "-45", episodes[0].DeepestBps.String())
+	assert.False(t, episodes[0].Ongoing)
+	assert.True(t, episodes[1].Ongoing)
+}
@@ -12,9 +12,22 @@ import (
 	"time"
 )
 
+// cacheTTL bounds how long a replica serves a cached summary or coverage whose invalidation it missed, they are
+// invalidated as the data sync updates the series
+const cacheTTL = time.Hour
+
 type ServicesModel struct {
 	AlphaVantageEconomicService EconomicService
 	Economicdashservice         EconomicDashboardService
+	EconomicCompareService      EconomicCompareService
+	TreasuryService             TreasuryService
+	AnalyticsService            AnalyticsService
+	CatalogService              CatalogService
+	RevisionService             RevisionService
+	IndicatorService            IndicatorService
+	ForecastService             ForecastService
+	AnomalyService              AnomalyService
+	StreamService               StreamService
 	UserService                 UserService
 	PermissionsService          PermissionsService
 	TokenService                TokenService
@@ -23,33 +36,106 @@ type ServicesModel struct {
 func NewServicesModel(models repo.Models, client alpha.ClientInterface, mailer *mailer.Mailer) ServicesModel {
 	newTokenService := NewTokenService(models.TokenRepository)
 	newUserService := NewUserService(models.UserRepository, models.PermissionsRepository, newTokenService, mailer)
+	streamService := economic.StreamService{EventRepository: models.EventRepository, Broker: economic.NewEventBroker()}
+	summaryCache := economic.NewSummaryCache(cacheTTL, streamService.Broker)
+	coverageCache := economic.NewCoverageCache(cacheTTL, streamService.Broker)
 
 	return ServicesModel{
 		AlphaVantageEconomicService: alpha.AlphaVantageEconomicService{
 			EconomicRepository: models.EconomicRepository,
 			ReportRepository:   models.ReportRepository,
 			Client:             client,
+			Events:             streamService,
+			Cache:              alpha.CacheInvalidators{summaryCache, coverageCache},
 			Limiter: alpha.AlphaVantageLimiter{
 				MinuteLimiter: rate.NewLimiter(rate.Every(1*time.Minute), 5),
 				DailyLimiter:  rate.NewLimiter(rate.Every(24*time.Hour), 500),
 			},
 		},
-		Economicdashservice: economic.DashboardService{EconomicRepository: models.EconomicRepository},
-		TokenService:        newTokenService,
-		UserService:         newUserService,
-		PermissionsService:  NewPermissionsService(models.PermissionsRepository),
+		Economicdashservice:    economic.DashboardService{EconomicRepository: models.EconomicRepository, DashboardRepository: models.DashboardRepository, Cache: summaryCache},
+		EconomicCompareService: economic.CompareService{EconomicRepository: models.EconomicRepository},
+		TreasuryService:        economic.TreasuryService{EconomicRepository: models.EconomicRepository},
+		AnalyticsService:       economic.AnalyticsService{EconomicRepository: models.EconomicRepository},
+		CatalogService:         economic.CatalogService{EconomicRepository: models.EconomicRepository, ReportRepository: models.ReportRepository, Cache: coverageCache},
+		RevisionService:        economic.RevisionService{EconomicRepository: models.EconomicRepository},
+		IndicatorService:       economic.IndicatorService{EconomicRepository: models.EconomicRepository},
+		ForecastService:        economic.ForecastService{EconomicRepository: models.EconomicRepository},
+		AnomalyService:         economic.AnomalyService{EconomicRepository: models.EconomicRepository},
+		StreamService:          streamService,
+		TokenService:           newTokenService,
+		UserService:            newUserService,
+		PermissionsService:     NewPermissionsService(models.PermissionsRepository),
 	}
 }
 
 type EconomicService interface {
 	GetAll(reportType data.ReportType) (*[]data.Economic, error)
-	GetIntervalWithPercentChange(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicWithChangeResult, errChan chan error, reportType data.ReportType, years int, paging data.Paging)
-	GetStats(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicStatsResult, errChan chan error, reportType data.ReportType, years int, timeBucket int, paging data.Paging)
+	GetIntervalWithPercentChange(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicWithChangeResult, errChan chan error, reportType data.ReportType, filter data.SeriesFilter, paging data.Paging)
+	EachWithPercentChange(ctx context.Context, reportType data.ReportType, filter data.SeriesFilter, fn func(economic data.EconomicWithChange) error) error
+	EachPageWithPercentChange(ctx context.Context, reportType data.ReportType, filter data.SeriesFilter, paging data.Paging, start func(meta data.Metadata) error, fn func(economic data.EconomicWithChange) error) error
+	GetStats(ctx context.Context, wg *sync.WaitGroup, dataChan chan data.EconomicStatsResult, errChan chan error, reportType data.ReportType, dateRange data.DateRange, timeBucket int, metrics data.StatsMetrics, paging data.Paging)
+	EachStats(ctx context.Context, reportType data.ReportType, dateRange data.DateRange, timeBucketDays int, metrics data.StatsMetrics, paging data.Paging, start func(meta data.Metadata) error, fn func(stats data.EconomicStats) error) error
 	StartDataSyncTask()
 }
 
 type EconomicDashboardService interface {
 	GetDashboardSummary() (*[]data.Summary, error)
+	Summaries(ctx context.Context, items data.DashboardItems) (*[]data.Summary, error)
+	Dashboards(ctx context.Context, userID int64) (*[]data.Dashboard, error)
+	Dashboard(ctx context.Context, id, userID int64) (*data.Dashboard, error)
+	CreateDashboard(ctx context.Context, dashboard *data.Dashboard) error
+	UpdateDashboard(ctx context.Context, dashboard *data.Dashboard) error
+	DeleteDashboard(ctx context.Context, id, userID int64) error
+	CacheStats() economic.CacheStats
+	Listen(ctx context.Context)
+}
+
+type EconomicCompareService interface {
+	Compare(ctx context.Context, reports []data.ReportType, filter data.SeriesFilter, alignment data.Alignment) (*[]data.ComparisonRow, error)
+}
+
+type TreasuryService interface {
+	YieldCurve(ctx context.Context, date time.Time) (*data.YieldCurve, error)
+	YieldCurveShift(curve, compare *data.YieldCurve) []data.YieldCurveShift
+	YieldSpread(ctx context.Context, long, short data.TreasuryMaturity, dateRange data.DateRange) (*data.YieldSpreadResult, error)
+}
+
+type AnalyticsService interface {
+	Rolling(ctx context.Context, report data.ReportType, filter data.SeriesFilter, window data.RollingWindow, paging data.Paging) (*data.RollingResult, error)
+	Correlation(ctx context.Context, reports []data.ReportType, filter data.SeriesFilter, method data.CorrelationMethod) (*data.CorrelationMatrix, error)
+}
+
+type CatalogService interface {
+	Reports(ctx context.Context) (*[]data.CatalogEntry, error)
+	Report(ctx context.Context, slug string) (*data.CatalogEntry, error)
+	CacheStats() economic.CoverageCacheStats
+	Listen(ctx context.Context)
+}
+
+type RevisionService interface {
+	History(ctx context.Context, report data.ReportType, date time.Time) (*data.RevisionHistory, error)
+	Summary(ctx context.Context, report data.ReportType, since time.Time, paging data.Paging) (*data.RevisionSummaryResult, error)
+}
+
+type IndicatorService interface {
+	Recession(ctx context.Context, dateRange data.DateRange) (*data.RecessionIndicators, error)
+}
+
+type ForecastService interface {
+	Forecast(ctx context.Context, report data.ReportType, model data.ForecastModel, horizon int) (*data.Forecast, error)
+}
+
+type AnomalyService interface {
+	Anomalies(ctx context.Context, since time.Time, paging data.Paging) (*data.AnomalyResult, error)
+}
+
+type StreamService interface {
+	Publish(ctx context.Context, event *data.EconomicEvent) error
+	Subscribe(slugs []string) *economic.EventSubscription
+	Unsubscribe(sub *economic.EventSubscription)
+	Replay(ctx context.Context, afterID int64, slugs []string) (*[]data.EconomicEvent, error)
+	LatestID(ctx context.Context) (int64, error)
+	Listen(ctx context.Context, listener data.EventListener) error
 }
 
 type UserService interface {
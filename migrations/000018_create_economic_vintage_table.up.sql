@@ -0,0 +1,39 @@
+-- ####################################################################################################
+-- economic_vintage
+-- ####################################################################################################
+CREATE TABLE IF NOT EXISTS economic_vintage (
+    slug TEXT NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    realtime_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    realtime_end TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (slug, time, realtime_start)
+);
+
+CREATE INDEX idx_economic_vintage_current ON economic_vintage(slug, time) WHERE realtime_end IS NULL;
+
+-- When data synced before vintages were stored was first published is unknown, so the vintages are approximate: an
+-- observation is taken to be known a publication lag after its reference date, which is the start of its period, or
+-- from now if that is still ahead. The lags are on the late side of each frequency's release schedule, so a point in
+-- time query may miss a print which was already out but never sees one before it was published
+DO $$
+DECLARE
+    report RECORD;
+BEGIN
+    FOR report IN
+        SELECT slug,
+            CASE
+                WHEN slug LIKE 'treasury_yield_%' THEN INTERVAL '1 day'
+                WHEN slug IN ('real_gdp', 'real_gdp_per_capita') THEN INTERVAL '4 months'
+                WHEN slug = 'inflation' THEN INTERVAL '18 months'
+                ELSE INTERVAL '2 months'
+            END AS publication_lag
+        FROM economic_report
+        WHERE to_regclass(slug) IS NOT NULL
+    LOOP
+        EXECUTE format(
+            'INSERT INTO economic_vintage (slug, time, value, realtime_start) SELECT %L, time, value, LEAST(time + %L::interval, NOW()) FROM %I ON CONFLICT DO NOTHING',
+            report.slug, report.publication_lag, report.slug
+        );
+    END LOOP;
+END $$;
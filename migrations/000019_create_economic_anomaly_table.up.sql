@@ -0,0 +1,16 @@
+-- ####################################################################################################
+-- economic_anomaly
+-- ####################################################################################################
+CREATE TABLE IF NOT EXISTS economic_anomaly (
+    slug TEXT NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    change DOUBLE PRECISION NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    seasonal_score DOUBLE PRECISION,
+    outlier BOOLEAN NOT NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (slug, time)
+);
+
+CREATE INDEX idx_economic_anomaly_outlier ON economic_anomaly(time) WHERE outlier;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS economic_event_notify ON economic_event;
+DROP FUNCTION IF EXISTS notify_economic_event();
+DROP TABLE IF EXISTS economic_event;
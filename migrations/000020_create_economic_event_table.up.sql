@@ -0,0 +1,32 @@
+-- ####################################################################################################
+-- economic_event
+-- ####################################################################################################
+CREATE TABLE IF NOT EXISTS economic_event (
+    id BIGSERIAL PRIMARY KEY,
+    slug TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_economic_event_slug ON economic_event(slug, id);
+
+-- Every replica of the API listens on the economic_event channel to push the events to its stream clients
+CREATE OR REPLACE FUNCTION notify_economic_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('economic_event', json_build_object(
+        'id', NEW.id,
+        'slug', NEW.slug,
+        'kind', NEW.kind,
+        'date', NEW.time,
+        'value', NEW.value,
+        'createdAt', NEW.created_at
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER economic_event_notify
+    AFTER INSERT ON economic_event
+    FOR EACH ROW EXECUTE FUNCTION notify_economic_event();
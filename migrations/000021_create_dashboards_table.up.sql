@@ -0,0 +1,14 @@
+-- ####################################################################################################
+-- dashboards
+-- ####################################################################################################
+CREATE TABLE IF NOT EXISTS dashboards (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- The series of the dashboard in the order they are shown, each with its change type
+    items JSONB NOT NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX idx_dashboards_user_id ON dashboards(user_id, id);
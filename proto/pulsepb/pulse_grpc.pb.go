@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.5
+// source: pulse.proto
+
+package pulsepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EconomicServiceClient is the client API for EconomicService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EconomicServiceClient interface {
+	ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error)
+	// GetSeries streams the observations of the date range, latest first
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (EconomicService_GetSeriesClient, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	GetDashboard(ctx context.Context, in *GetDashboardRequest, opts ...grpc.CallOption) (*GetDashboardResponse, error)
+}
+
+type economicServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEconomicServiceClient(cc grpc.ClientConnInterface) EconomicServiceClient {
+	return &economicServiceClient{cc}
+}
+
+func (c *economicServiceClient) ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error) {
+	out := new(ListReportsResponse)
+	err := c.cc.Invoke(ctx, "/pulse.v1.EconomicService/ListReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *economicServiceClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (EconomicService_GetSeriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EconomicService_ServiceDesc.Streams[0], "/pulse.v1.EconomicService/GetSeries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &economicServiceGetSeriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EconomicService_GetSeriesClient interface {
+	Recv() (*Observation, error)
+	grpc.ClientStream
+}
+
+type economicServiceGetSeriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *economicServiceGetSeriesClient) Recv() (*Observation, error) {
+	m := new(Observation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *economicServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, "/pulse.v1.EconomicService/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *economicServiceClient) GetDashboard(ctx context.Context, in *GetDashboardRequest, opts ...grpc.CallOption) (*GetDashboardResponse, error) {
+	out := new(GetDashboardResponse)
+	err := c.cc.Invoke(ctx, "/pulse.v1.EconomicService/GetDashboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EconomicServiceServer is the server API for EconomicService service.
+// All implementations must embed UnimplementedEconomicServiceServer
+// for forward compatibility
+type EconomicServiceServer interface {
+	ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error)
+	// GetSeries streams the observations of the date range, latest first
+	GetSeries(*GetSeriesRequest, EconomicService_GetSeriesServer) error
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	GetDashboard(context.Context, *GetDashboardRequest) (*GetDashboardResponse, error)
+	mustEmbedUnimplementedEconomicServiceServer()
+}
+
+// UnimplementedEconomicServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEconomicServiceServer struct {
+}
+
+func (UnimplementedEconomicServiceServer) ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedEconomicServiceServer) GetSeries(*GetSeriesRequest, EconomicService_GetSeriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (UnimplementedEconomicServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedEconomicServiceServer) GetDashboard(context.Context, *GetDashboardRequest) (*GetDashboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDashboard not implemented")
+}
+func (UnimplementedEconomicServiceServer) mustEmbedUnimplementedEconomicServiceServer() {}
+
+// UnsafeEconomicServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EconomicServiceServer will
+// result in compilation errors.
+type UnsafeEconomicServiceServer interface {
+	mustEmbedUnimplementedEconomicServiceServer()
+}
+
+func RegisterEconomicServiceServer(s grpc.ServiceRegistrar, srv EconomicServiceServer) {
+	s.RegisterService(&EconomicService_ServiceDesc, srv)
+}
+
+func _EconomicService_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EconomicServiceServer).ListReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulse.v1.EconomicService/ListReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EconomicServiceServer).ListReports(ctx, req.(*ListReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EconomicService_GetSeries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSeriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EconomicServiceServer).GetSeries(m, &economicServiceGetSeriesServer{stream})
+}
+
+type EconomicService_GetSeriesServer interface {
+	Send(*Observation) error
+	grpc.ServerStream
+}
+
+type economicServiceGetSeriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *economicServiceGetSeriesServer) Send(m *Observation) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EconomicService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EconomicServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulse.v1.EconomicService/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EconomicServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EconomicService_GetDashboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDashboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EconomicServiceServer).GetDashboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulse.v1.EconomicService/GetDashboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EconomicServiceServer).GetDashboard(ctx, req.(*GetDashboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EconomicService_ServiceDesc is the grpc.ServiceDesc for EconomicService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EconomicService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pulse.v1.EconomicService",
+	HandlerType: (*EconomicServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReports",
+			Handler:    _EconomicService_ListReports_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _EconomicService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetDashboard",
+			Handler:    _EconomicService_GetDashboard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetSeries",
+			Handler:       _EconomicService_GetSeries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pulse.proto",
+}